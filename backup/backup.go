@@ -0,0 +1,164 @@
+// Package backup dumps every table of the bot to a versioned JSON archive and
+// restores such an archive into any Store, e.g. to copy prod data to dev:
+//
+//	ENV=prod telegram-bot backup -o club.json
+//	telegram-bot restore -to dynamodb:dev club.json
+//
+// The file backend keeps the tables as JSON files in a directory instead:
+//
+//	telegram-bot restore -to file:./club club.json
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"telegram-bot/database"
+	"time"
+)
+
+// Version is the archive format written by Backup. Restore refuses newer archives.
+const Version = 1
+
+// Archive is the contents of a backup file.
+type Archive struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Source    string            `json:"source"`
+	Tables    map[string][]Item `json:"tables"`
+}
+
+// Backup reads every table from store.
+func Backup(store Store, source string) (Archive, error) {
+	archive := Archive{Version: Version, CreatedAt: time.Now().UTC(), Source: source, Tables: map[string][]Item{}}
+	for _, table := range database.Tables() {
+		items, err := store.Scan(table)
+		if err != nil {
+			return Archive{}, err
+		}
+		if items == nil {
+			items = []Item{}
+		}
+		archive.Tables[table] = items
+	}
+	return archive, nil
+}
+
+// Restore writes every table of the archive to store. Items with the same key
+// are replaced; items missing from the archive are left alone.
+func Restore(store Store, archive Archive) error {
+	if archive.Version < 1 || archive.Version > Version {
+		return fmt.Errorf("unsupported archive version %d, this build reads up to %d", archive.Version, Version)
+	}
+	known := map[string]bool{}
+	for _, table := range database.Tables() {
+		known[table] = true
+	}
+	for table := range archive.Tables {
+		if !known[table] {
+			return fmt.Errorf("the archive has an unknown table %q", table)
+		}
+	}
+	for _, table := range database.Tables() {
+		if err := store.Put(table, archive.Tables[table]); err != nil {
+			return err
+		}
+		log.Printf("Restored %d items to %s", len(archive.Tables[table]), table)
+	}
+	return nil
+}
+
+// Write encodes the archive as indented JSON.
+func Write(w io.Writer, archive Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// Read decodes an archive, keeping numbers exact.
+func Read(r io.Reader) (Archive, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var archive Archive
+	if err := decoder.Decode(&archive); err != nil {
+		return Archive{}, err
+	}
+	return archive, nil
+}
+
+// IsCommand reports whether the bot was started as "telegram-bot backup" or
+// "telegram-bot restore" instead of as the bot itself.
+func IsCommand(args []string) bool {
+	return len(args) > 0 && (args[0] == "backup" || args[0] == "restore")
+}
+
+// Run carries out the backup or restore subcommand given in args.
+func Run(args []string) error {
+	if len(args) > 0 && args[0] == "backup" {
+		return runBackup(args[1:])
+	}
+	if len(args) > 0 && args[0] == "restore" {
+		return runRestore(args[1:])
+	}
+	return errors.New("usage: telegram-bot backup|restore [flags]")
+}
+
+func runBackup(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	from := flags.String("from", "dynamodb:"+os.Getenv("ENV"), "the store to back up")
+	output := flags.String("o", "", "the archive to write, standard output if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	store, err := Open(*from)
+	if err != nil {
+		return err
+	}
+	archive, err := Backup(store, *from)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		return Write(os.Stdout, archive)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := Write(file, archive); err != nil {
+		file.Close()
+		return err
+	}
+	log.Printf("Backed up %s to %s", *from, *output)
+	return file.Close()
+}
+
+func runRestore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	to := flags.String("to", "dynamodb:"+os.Getenv("ENV"), "the store to restore into")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: telegram-bot restore [-to backend:option] archive.json")
+	}
+	store, err := Open(*to)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	archive, err := Read(file)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", flags.Arg(0), err)
+	}
+	log.Printf("Restoring the backup of %s from %s to %s", archive.Source, archive.CreatedAt.Format(time.RFC3339), *to)
+	return Restore(store, archive)
+}
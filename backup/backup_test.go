@@ -0,0 +1,162 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"telegram-bot/database"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// book has an ID beyond float64 precision and nested editions and milestones.
+func book() Item {
+	return Item{
+		"BookID": json.Number("1760868000123456789"),
+		"Title":  "Dune",
+		"Active": true,
+		"Year":   json.Number("1965"),
+		"Editions": []interface{}{
+			Item{"Name": "Ace", "Pages": json.Number("896")},
+			Item{"Name": "", "Pages": json.Number("412")},
+		},
+		"Milestones": []interface{}{
+			Item{"Title": "Book one", "Percent": json.Number("33"), "Deadline": "01.11.2026", "Reminded": false},
+		},
+		"CoverURL": nil,
+	}
+}
+
+func TestKeysCoverEveryTable(t *testing.T) {
+	for _, table := range database.Tables() {
+		if len(keys[table]) == 0 {
+			t.Errorf("no keys for table %s", table)
+		}
+	}
+	if len(keys) != len(database.Tables()) {
+		t.Errorf("keys has %d tables, the database %d", len(keys), len(database.Tables()))
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	source, err := Open("file:" + t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := []Item{{"UserName": "alice", "FullName": "Alice", "IsAdmin": true}}
+	progress := []Item{{"BookID": json.Number("1760868000123456789"), "UserName": "alice", "Progress": json.Number("42"), "Type": "regular"}}
+	for table, items := range map[string][]Item{"books": {book()}, "users": users, "reading_progress": progress} {
+		if err := source.Put(table, items); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := Backup(source, "file:test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, archive); err != nil {
+		t.Fatal(err)
+	}
+	read, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := Open("file:" + t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(target, read); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range database.Tables() {
+		want, _ := source.Scan(table)
+		got, err := target.Scan(table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(want) == 0 && len(got) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s after the round trip:\n got %#v\nwant %#v", table, got, want)
+		}
+	}
+	books, _ := target.Scan("books")
+	if id := books[0]["BookID"]; id != json.Number("1760868000123456789") {
+		t.Errorf("BookID = %#v, want the exact json.Number", id)
+	}
+}
+
+func TestRestoreReplacesByKey(t *testing.T) {
+	store, err := Open("file:" + t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put("users", []Item{{"UserName": "alice", "FullName": "Alice"}, {"UserName": "bob", "FullName": "Bob"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put("users", []Item{{"UserName": "alice", "FullName": "Alice Liddell"}}); err != nil {
+		t.Fatal(err)
+	}
+	users, err := store.Scan("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Item{{"UserName": "alice", "FullName": "Alice Liddell"}, {"UserName": "bob", "FullName": "Bob"}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("users = %v, want %v", users, want)
+	}
+}
+
+func TestRestoreRejects(t *testing.T) {
+	store, err := Open("file:" + t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(store, Archive{Version: Version + 1}); err == nil {
+		t.Error("restored an archive from a newer version")
+	}
+	if err := Restore(store, Archive{Version: Version, Tables: map[string][]Item{"unknown": {}}}); err == nil {
+		t.Error("restored an unknown table")
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	item := book()
+	attribute, err := toAttribute(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := attribute.M["BookID"].N; n == nil || *n != "1760868000123456789" {
+		t.Errorf("BookID attribute = %v, want N 1760868000123456789", attribute.M["BookID"])
+	}
+	if pages := attribute.M["Editions"].L[0].M["Pages"].N; pages == nil || *pages != "896" {
+		t.Errorf("edition pages attribute = %v, want N 896", attribute.M["Editions"].L[0])
+	}
+	if attribute.M["CoverURL"].NULL == nil || !*attribute.M["CoverURL"].NULL {
+		t.Errorf("CoverURL attribute = %v, want NULL", attribute.M["CoverURL"])
+	}
+	// DynamoDB reports NULL attributes back as NULL, which the archive keeps as null.
+	if got := fromAttribute(attribute); !reflect.DeepEqual(got, item) {
+		t.Errorf("fromAttribute(toAttribute(item)) = %#v, want %#v", got, item)
+	}
+
+	sets := &dynamodb.AttributeValue{M: map[string]*dynamodb.AttributeValue{
+		"Tags":  {SS: []*string{aws.String("a"), aws.String("b")}},
+		"Ids":   {NS: []*string{aws.String("1"), aws.String("2")}},
+		"Cover": {B: []byte("png")},
+	}}
+	want := Item{"Tags": []interface{}{"a", "b"}, "Ids": []interface{}{json.Number("1"), json.Number("2")}, "Cover": "cG5n"}
+	if got := fromAttribute(sets); !reflect.DeepEqual(got, want) {
+		t.Errorf("fromAttribute(sets) = %#v, want %#v", got, want)
+	}
+
+	if _, err := toAttribute(Item{"Score": 4.5}); err == nil {
+		t.Error("toAttribute accepted a float64; numbers must be json.Number")
+	}
+}
@@ -0,0 +1,284 @@
+package backup
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"telegram-bot/database"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// Item is one stored record as plain JSON values. Numbers are kept as
+// json.Number so IDs and counters survive the round trip exactly.
+type Item = map[string]interface{}
+
+// Store is a storage backend that a backup can be read from or restored into.
+type Store interface {
+	// Scan returns every item of table, e.g. "users".
+	Scan(table string) ([]Item, error)
+	// Put writes items to table, replacing items with the same key.
+	Put(table string, items []Item) error
+}
+
+// backends open a Store from the part of the address after the colon.
+var backends = map[string]func(string) (Store, error){
+	"dynamodb": openDynamoDB,
+	"file":     openFiles,
+}
+
+// keys are the key attributes of every table as set up in DynamoDB. Stores
+// other than DynamoDB use them to tell which items Put replaces.
+var keys = map[string][]string{
+	"users":            {"UserName"},
+	"books":            {"BookID"},
+	"reading_progress": {"BookID", "UserName"},
+	"meetings":         {"MeetingID"},
+	"rsvps":            {"MeetingID", "UserName"},
+	"ratings":          {"BookID", "UserName"},
+	"notes":            {"BookID", "NoteID"},
+	"quotes":           {"BookID", "QuoteID"},
+	"progress_history": {"UserName", "Timestamp"},
+	"shelf":            {"UserName", "ShelfID"},
+	"settings":         {"ClubID"},
+	"audit":            {"EventID"},
+}
+
+// Open returns the store at address, written as "<backend>:<option>",
+// e.g. "dynamodb:dev" or "file:./club".
+func Open(address string) (Store, error) {
+	backend, option, _ := strings.Cut(address, ":")
+	open, ok := backends[backend]
+	if !ok {
+		var names []string
+		for name := range backends {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown backend %q, use one of: %s", backend, strings.Join(names, ", "))
+	}
+	return open(option)
+}
+
+// dynamoDB is a store in the DynamoDB tables of one environment.
+type dynamoDB struct {
+	svc         *dynamodb.DynamoDB
+	environment string
+}
+
+func openDynamoDB(environment string) (Store, error) {
+	if database.TableName("users", environment) == "" {
+		return nil, fmt.Errorf("unknown DynamoDB environment %q, use prod or dev", environment)
+	}
+	return &dynamoDB{svc: dynamodb.New(database.AWSsession()), environment: environment}, nil
+}
+
+func (s *dynamoDB) tableName(table string) (string, error) {
+	name := database.TableName(table, s.environment)
+	if name == "" {
+		return "", fmt.Errorf("there is no %s table in %s", table, s.environment)
+	}
+	return name, nil
+}
+
+func (s *dynamoDB) Scan(table string) ([]Item, error) {
+	name, err := s.tableName(table)
+	if err != nil {
+		return nil, err
+	}
+	var items []Item
+	err = s.svc.ScanPages(&dynamodb.ScanInput{TableName: aws.String(name)}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		for _, attributes := range page.Items {
+			items = append(items, fromAttribute(&dynamodb.AttributeValue{M: attributes}).(Item))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", name, err)
+	}
+	return items, nil
+}
+
+func (s *dynamoDB) Put(table string, items []Item) error {
+	name, err := s.tableName(table)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		attribute, err := toAttribute(item)
+		if err != nil {
+			return fmt.Errorf("%s: %w", table, err)
+		}
+		_, err = s.svc.PutItem(&dynamodb.PutItemInput{TableName: aws.String(name), Item: attribute.M})
+		if err != nil {
+			return fmt.Errorf("put into %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// files is a store in a directory with one JSON file per table, e.g. to inspect
+// a backup or to try a restore without touching DynamoDB.
+type files struct {
+	dir string
+}
+
+func openFiles(dir string) (Store, error) {
+	if dir == "" {
+		return nil, errors.New("the file backend needs a directory, e.g. file:./club")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &files{dir: dir}, nil
+}
+
+func (s *files) path(table string) (string, error) {
+	if keys[table] == nil {
+		return "", fmt.Errorf("there is no %s table", table)
+	}
+	return filepath.Join(s.dir, table+".json"), nil
+}
+
+// Scan returns no items for a table that has no file yet.
+func (s *files) Scan(table string) ([]Item, error) {
+	path, err := s.path(table)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+	var items []Item
+	if err := decoder.Decode(&items); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return items, nil
+}
+
+func (s *files) Put(table string, items []Item) error {
+	path, err := s.path(table)
+	if err != nil {
+		return err
+	}
+	stored, err := s.Scan(table)
+	if err != nil {
+		return err
+	}
+	index := map[string]int{}
+	for i, item := range stored {
+		index[itemKey(table, item)] = i
+	}
+	for _, item := range items {
+		key := itemKey(table, item)
+		if i, ok := index[key]; ok {
+			stored[i] = item
+			continue
+		}
+		index[key] = len(stored)
+		stored = append(stored, item)
+	}
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %w", table, err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// itemKey joins the key attributes of an item of table.
+func itemKey(table string, item Item) string {
+	var parts []string
+	for _, attribute := range keys[table] {
+		parts = append(parts, fmt.Sprint(item[attribute]))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// fromAttribute converts a DynamoDB value to plain JSON values. The bot stores no
+// sets or binary values; should any appear they come back as lists and strings.
+func fromAttribute(av *dynamodb.AttributeValue) interface{} {
+	switch {
+	case av.S != nil:
+		return *av.S
+	case av.N != nil:
+		return json.Number(*av.N)
+	case av.BOOL != nil:
+		return *av.BOOL
+	case av.M != nil:
+		m := Item{}
+		for key, value := range av.M {
+			m[key] = fromAttribute(value)
+		}
+		return m
+	case av.L != nil:
+		l := make([]interface{}, len(av.L))
+		for i, value := range av.L {
+			l[i] = fromAttribute(value)
+		}
+		return l
+	case av.B != nil:
+		return base64.StdEncoding.EncodeToString(av.B)
+	case av.SS != nil:
+		l := make([]interface{}, len(av.SS))
+		for i, s := range av.SS {
+			l[i] = *s
+		}
+		return l
+	case av.NS != nil:
+		l := make([]interface{}, len(av.NS))
+		for i, n := range av.NS {
+			l[i] = json.Number(*n)
+		}
+		return l
+	}
+	return nil
+}
+
+// toAttribute converts plain JSON values, decoded with UseNumber, back to a DynamoDB value.
+func toAttribute(value interface{}) (*dynamodb.AttributeValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return &dynamodb.AttributeValue{NULL: aws.Bool(true)}, nil
+	case string:
+		return &dynamodb.AttributeValue{S: aws.String(v)}, nil
+	case json.Number:
+		return &dynamodb.AttributeValue{N: aws.String(v.String())}, nil
+	case bool:
+		return &dynamodb.AttributeValue{BOOL: aws.Bool(v)}, nil
+	case map[string]interface{}:
+		m := map[string]*dynamodb.AttributeValue{}
+		for key, value := range v {
+			attribute, err := toAttribute(value)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = attribute
+		}
+		return &dynamodb.AttributeValue{M: m}, nil
+	case []interface{}:
+		l := make([]*dynamodb.AttributeValue, len(v))
+		for i, value := range v {
+			attribute, err := toAttribute(value)
+			if err != nil {
+				return nil, err
+			}
+			l[i] = attribute
+		}
+		return &dynamodb.AttributeValue{L: l}, nil
+	}
+	return nil, fmt.Errorf("unsupported value %v of type %T", value, value)
+}
@@ -0,0 +1,52 @@
+package bookinfo
+
+import (
+	"os"
+	"strings"
+)
+
+// Result is a book found by a metadata provider.
+type Result struct {
+	Title    string `json:"title"`
+	Author   string `json:"author"`
+	ISBN     string `json:"isbn"`
+	Pages    int    `json:"pages"`
+	Year     int    `json:"year"`
+	CoverURL string `json:"cover_url"`
+}
+
+// Provider looks books up by ISBN or free-text query.
+type Provider interface {
+	Search(query string) ([]Result, error)
+}
+
+// MaxResults is how many results are offered to the admin.
+const MaxResults = 5
+
+// FromEnv returns the provider configured with METADATA_PROVIDER:
+// "fixture" reads METADATA_FIXTURE, anything else queries an Open Library-compatible
+// API at METADATA_URL (https://openlibrary.org by default).
+func FromEnv() Provider {
+	if os.Getenv("METADATA_PROVIDER") == "fixture" {
+		path := os.Getenv("METADATA_FIXTURE")
+		if path == "" {
+			path = "bookinfo/fixtures/books.json"
+		}
+		return NewFixture(path)
+	}
+	return NewOpenLibrary(os.Getenv("METADATA_URL"))
+}
+
+// NormalizeISBN returns the query without hyphens and spaces if it looks like an ISBN.
+func NormalizeISBN(query string) (string, bool) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(query))
+	if len(isbn) != 10 && len(isbn) != 13 {
+		return "", false
+	}
+	for i, r := range isbn {
+		if (r < '0' || r > '9') && !(r == 'X' && i == len(isbn)-1) {
+			return "", false
+		}
+	}
+	return isbn, true
+}
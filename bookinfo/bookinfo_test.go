@@ -0,0 +1,120 @@
+package bookinfo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+		ok    bool
+	}{
+		{"978-0-14-118014-4", "9780141180144", true},
+		{"0 14 118014 x", "014118014X", true},
+		{"014118014X", "014118014X", true},
+		{"01411X0144", "", false},
+		{"978014118014", "", false},
+		{"the master and margarita", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeISBN(tt.query)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeISBN(%q) = %q, %v, want %q, %v", tt.query, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFixtureSearch(t *testing.T) {
+	fixture := NewFixture("fixtures/books.json")
+
+	results, err := fixture.Search("978-0-14-118014-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Title != "The Master and Margarita" || results[0].Pages != 448 {
+		t.Errorf("ISBN search = %+v, want The Master and Margarita", results)
+	}
+
+	results, err = fixture.Search("dostoevsky")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) < 2 {
+		t.Errorf("author search found %d books, want at least 2", len(results))
+	}
+	for _, result := range results {
+		if result.Author != "Fyodor Dostoevsky" {
+			t.Errorf("author search returned %q by %q", result.Title, result.Author)
+		}
+	}
+
+	if _, err := NewFixture("fixtures/missing.json").Search("x"); err == nil {
+		t.Error("search in a missing fixture succeeded")
+	}
+}
+
+func TestOpenLibrarySearch(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search.json" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"docs": [
+			{"title": "Crime and Punishment", "author_name": ["Fyodor Dostoevsky", "Constance Garnett"],
+			 "isbn": ["9780143058144", "0143058142"], "number_of_pages_median": 576,
+			 "first_publish_year": 1866, "cover_i": 12345},
+			{"title": "Untitled draft"}
+		]}`))
+	}))
+	defer server.Close()
+
+	results, err := NewOpenLibrary(server.URL + "/").Search("978-0-14-305814-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "isbn=9780143058144"; !strings.Contains(query, want) {
+		t.Errorf("query %q doesn't contain %q", query, want)
+	}
+	want := Result{
+		Title:    "Crime and Punishment",
+		Author:   "Fyodor Dostoevsky, Constance Garnett",
+		ISBN:     "9780143058144",
+		Pages:    576,
+		Year:     1866,
+		CoverURL: "https://covers.openlibrary.org/b/id/12345-L.jpg",
+	}
+	if len(results) != 2 || results[0] != want || results[1] != (Result{Title: "Untitled draft"}) {
+		t.Errorf("results = %+v, want %+v and an untitled draft", results, want)
+	}
+
+	if _, err := NewOpenLibrary(server.URL).Search("dostoevsky"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "q=dostoevsky"; !strings.Contains(query, want) {
+		t.Errorf("query %q doesn't contain %q", query, want)
+	}
+}
+
+func TestOpenLibraryErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") == "broken" {
+			w.Write([]byte(`{"docs": [`))
+			return
+		}
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	for _, query := range []string{"anything", "broken"} {
+		if _, err := NewOpenLibrary(server.URL).Search(query); err == nil {
+			t.Errorf("Search(%q) succeeded, want an error", query)
+		}
+	}
+}
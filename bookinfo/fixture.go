@@ -0,0 +1,43 @@
+package bookinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Fixture serves results from a local JSON file, so the bot can run without network access.
+type Fixture struct {
+	Path string
+}
+
+func NewFixture(path string) *Fixture {
+	return &Fixture{Path: path}
+}
+
+func (f *Fixture) Search(query string) ([]Result, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+	var books []Result
+	if err := json.Unmarshal(data, &books); err != nil {
+		return nil, fmt.Errorf("parse fixture %s: %w", f.Path, err)
+	}
+
+	isbn, isISBN := NormalizeISBN(query)
+	query = strings.ToLower(strings.TrimSpace(query))
+	var results []Result
+	for _, book := range books {
+		matches := isISBN && book.ISBN == isbn ||
+			!isISBN && (strings.Contains(strings.ToLower(book.Title), query) || strings.Contains(strings.ToLower(book.Author), query))
+		if matches {
+			results = append(results, book)
+		}
+		if len(results) == MaxResults {
+			break
+		}
+	}
+	return results, nil
+}
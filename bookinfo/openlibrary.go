@@ -0,0 +1,83 @@
+package bookinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenLibrary queries the search API of openlibrary.org or a compatible server.
+type OpenLibrary struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+func NewOpenLibrary(baseURL string) *OpenLibrary {
+	if baseURL == "" {
+		baseURL = "https://openlibrary.org"
+	}
+	return &OpenLibrary{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type searchResponse struct {
+	Docs []struct {
+		Title            string   `json:"title"`
+		AuthorName       []string `json:"author_name"`
+		ISBN             []string `json:"isbn"`
+		NumberOfPages    int      `json:"number_of_pages_median"`
+		FirstPublishYear int      `json:"first_publish_year"`
+		CoverID          int      `json:"cover_i"`
+	} `json:"docs"`
+}
+
+func (o *OpenLibrary) Search(query string) ([]Result, error) {
+	params := url.Values{}
+	if isbn, ok := NormalizeISBN(query); ok {
+		params.Set("isbn", isbn)
+	} else {
+		params.Set("q", query)
+	}
+	params.Set("limit", strconv.Itoa(MaxResults))
+	params.Set("fields", "title,author_name,isbn,number_of_pages_median,first_publish_year,cover_i")
+
+	resp, err := o.Client.Get(o.BaseURL + "/search.json?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open library search: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("open library search: unexpected status %s", resp.Status)
+	}
+
+	var body searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("open library search: %w", err)
+	}
+
+	var results []Result
+	for _, doc := range body.Docs {
+		result := Result{
+			Title: doc.Title,
+			Pages: doc.NumberOfPages,
+			Year:  doc.FirstPublishYear,
+		}
+		if len(doc.AuthorName) > 0 {
+			result.Author = strings.Join(doc.AuthorName, ", ")
+		}
+		if len(doc.ISBN) > 0 {
+			result.ISBN = doc.ISBN[0]
+		}
+		if doc.CoverID != 0 {
+			result.CoverURL = fmt.Sprintf("https://covers.openlibrary.org/b/id/%d-L.jpg", doc.CoverID)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
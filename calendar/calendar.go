@@ -0,0 +1,150 @@
+package calendar
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"telegram-bot/database"
+	"time"
+)
+
+// meetingDuration is used as the end of timed meetings, which have no explicit end.
+const meetingDuration = 2 * time.Hour
+
+// ClubID identifies the club in the feed URL.
+func ClubID() string {
+	if club := os.Getenv("CLUB_ID"); club != "" {
+		return club
+	}
+	return "book-club"
+}
+
+// FeedURL returns the subscription URL of the club's feed,
+// or "" if PUBLIC_URL or CALENDAR_TOKEN is not configured.
+func FeedURL() string {
+	baseURL := strings.TrimSuffix(os.Getenv("PUBLIC_URL"), "/")
+	token := os.Getenv("CALENDAR_TOKEN")
+	if baseURL == "" || token == "" {
+		return ""
+	}
+	return baseURL + "/clubs/" + ClubID() + "/calendar.ics?token=" + token
+}
+
+// AllMeetings returns the meetings of every book the club has read or scheduled.
+func AllMeetings() ([]database.Meeting, map[string]database.Book, error) {
+	bookList, err := database.FetchBooks()
+	if err != nil {
+		return nil, nil, err
+	}
+	meetings, err := database.FetchBookMeetings(bookList)
+	if err != nil {
+		return nil, nil, err
+	}
+	books := map[string]database.Book{}
+	for _, book := range bookList {
+		books[book.BookID] = book
+	}
+	return meetings, books, nil
+}
+
+// FeedHandler serves the club's meetings as an iCalendar feed at
+// /clubs/{club}/calendar.ics?token=...
+func FeedHandler(w http.ResponseWriter, r *http.Request) {
+	token := os.Getenv("CALENDAR_TOKEN")
+	given := r.URL.Query().Get("token")
+	if r.PathValue("club") != ClubID() || token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	meetings, books, err := AllMeetings()
+	if err != nil {
+		log.Printf("Failed to read meetings for the calendar feed: %s", err)
+		http.Error(w, "Failed to read meetings", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if _, err := w.Write(ICS(meetings, books)); err != nil {
+		log.Printf("Failed to write calendar feed: %s", err)
+	}
+}
+
+// ICS renders meetings as an iCalendar document.
+func ICS(meetings []database.Meeting, books map[string]database.Book) []byte {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//book-club-bot//"+ClubID()+"//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "X-WR-CALNAME:Book club")
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, meeting := range meetings {
+		start, err := meeting.Start()
+		if err != nil {
+			log.Printf("Skipping meeting '%s' with invalid date: %s", meeting.MeetingID, err)
+			continue
+		}
+		book := books[meeting.BookID]
+
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+meeting.MeetingID+"@"+ClubID())
+		writeLine(&b, "DTSTAMP:"+stamp)
+		if meeting.Time == "" {
+			writeLine(&b, "DTSTART;VALUE=DATE:"+start.Format("20060102"))
+			writeLine(&b, "DTEND;VALUE=DATE:"+start.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			writeLine(&b, "DTSTART:"+start.UTC().Format("20060102T150405Z"))
+			writeLine(&b, "DTEND:"+start.Add(meetingDuration).UTC().Format("20060102T150405Z"))
+		}
+		summary := meeting.Title
+		if book.Title != "" {
+			summary += ": " + book.Title
+		}
+		writeLine(&b, "SUMMARY:"+escape(summary))
+		if meeting.Location != "" {
+			writeLine(&b, "LOCATION:"+escape(meeting.Location))
+		} else if meeting.Link != "" {
+			writeLine(&b, "LOCATION:"+escape(meeting.Link))
+		}
+		if meeting.Link != "" {
+			writeLine(&b, "URL:"+meeting.Link)
+		}
+		description := ""
+		if book.Title != "" {
+			description = fmt.Sprintf("%s by %s", book.Title, book.Author)
+		}
+		if meeting.Agenda != "" {
+			description += "\n" + meeting.Agenda
+		}
+		if description != "" {
+			writeLine(&b, "DESCRIPTION:"+escape(strings.TrimSpace(description)))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// writeLine folds content lines longer than 75 octets as required by RFC 5545.
+func writeLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Don't split multi-byte characters.
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = 74
+	}
+	b.WriteString(line + "\r\n")
+}
@@ -0,0 +1,105 @@
+package calendar
+
+import (
+	"strings"
+	"telegram-bot/database"
+	"testing"
+)
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines []string
+	}{
+		{"short", "SUMMARY:Book club", []string{"SUMMARY:Book club"}},
+		{"exactly 75 octets", strings.Repeat("a", 75), []string{strings.Repeat("a", 75)}},
+		{"76 octets", strings.Repeat("a", 76), []string{strings.Repeat("a", 75), " a"}},
+		{
+			"continuation holds 74 octets",
+			strings.Repeat("a", 75+74+1),
+			[]string{strings.Repeat("a", 75), " " + strings.Repeat("a", 74), " a"},
+		},
+		{
+			"multi-byte rune on the boundary",
+			strings.Repeat("a", 74) + "ё" + "b",
+			[]string{strings.Repeat("a", 74), " ёb"},
+		},
+		{
+			"multi-byte rune on a continuation boundary",
+			strings.Repeat("a", 75) + strings.Repeat("b", 73) + "ё",
+			[]string{strings.Repeat("a", 75), " " + strings.Repeat("b", 73), " ё"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b strings.Builder
+			writeLine(&b, test.line)
+			got := b.String()
+			want := strings.Join(test.lines, "\r\n") + "\r\n"
+			if got != want {
+				t.Errorf("writeLine(%q) = %q, want %q", test.line, got, want)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+				if len(line) > 75 {
+					t.Errorf("line %q is %d octets", line, len(line))
+				}
+			}
+			if unfolded := strings.ReplaceAll(strings.TrimSuffix(got, "\r\n"), "\r\n ", ""); unfolded != test.line {
+				t.Errorf("unfolded %q, want %q", unfolded, test.line)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Anna Karenina", "Anna Karenina"},
+		{"Tea, cake; books", `Tea\, cake\; books`},
+		{`C:\club`, `C:\\club`},
+		{"Chapter 1\nChapter 2", `Chapter 1\nChapter 2`},
+		{"Chapter 1\r\nChapter 2", `Chapter 1\nChapter 2`},
+		{`\,`, `\\\,`},
+	}
+	for _, test := range tests {
+		if got := escape(test.in); got != test.want {
+			t.Errorf("escape(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestICSStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		meeting database.Meeting
+		want    []string
+	}{
+		{
+			"all-day",
+			database.Meeting{MeetingID: "1", Date: "19.01.2026", Timezone: "Europe/Berlin"},
+			[]string{"DTSTART;VALUE=DATE:20260119\r\n", "DTEND;VALUE=DATE:20260120\r\n"},
+		},
+		{
+			"timed",
+			database.Meeting{MeetingID: "2", Date: "19.01.2026", Time: "18:30", Timezone: "Europe/Berlin"},
+			[]string{"DTSTART:20260119T173000Z\r\n", "DTEND:20260119T193000Z\r\n"},
+		},
+		{
+			"timed in summer time",
+			database.Meeting{MeetingID: "3", Date: "19.07.2026", Time: "18:30", Timezone: "Europe/Berlin"},
+			[]string{"DTSTART:20260719T163000Z\r\n", "DTEND:20260719T183000Z\r\n"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ics := string(ICS([]database.Meeting{test.meeting}, nil))
+			for _, line := range test.want {
+				if !strings.Contains(ics, line) {
+					t.Errorf("missing %q in:\n%s", line, ics)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package commandhandler
+
+import (
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+	"time"
+)
+
+// auditLimit is how many entries /audit shows at most.
+const auditLimit = 20
+
+const auditUsage = "Filter the audit log with any of:\n" +
+	"@alice or user:alice - actions by or about @alice\n" +
+	"action:RemoveUser - one of %s\n" +
+	"from:01.09.2024 and to:30.09.2024 - a date range\n" +
+	"e.g. /audit @alice action:AddBook from:01.09.2024"
+
+// auditFilter selects audit entries. Empty fields match everything.
+type auditFilter struct {
+	user   string
+	action string
+	from   time.Time
+	to     time.Time // exclusive
+}
+
+func parseAuditFilter(arguments string, location *time.Location) (auditFilter, bool) {
+	var filter auditFilter
+	for _, field := range strings.Fields(arguments) {
+		key, value, found := strings.Cut(field, ":")
+		if !found && strings.HasPrefix(field, "@") {
+			key, value = "user", field
+		}
+		switch key = strings.ToLower(key); key {
+		case "user":
+			filter.user = strings.TrimPrefix(value, "@")
+		case "action":
+			for _, action := range database.AuditActions {
+				if strings.EqualFold(value, action) {
+					filter.action = action
+				}
+			}
+			if filter.action == "" {
+				return filter, false
+			}
+		case "from", "to":
+			date, err := utils.ParseDate(value, location)
+			if err != nil {
+				return filter, false
+			}
+			if key == "from" {
+				filter.from = date
+			} else {
+				filter.to = date.AddDate(0, 0, 1)
+			}
+		default:
+			return filter, false
+		}
+	}
+	return filter, true
+}
+
+func (f auditFilter) match(entry database.AuditEntry) bool {
+	if f.user != "" && !strings.EqualFold(entry.Actor, f.user) && !strings.EqualFold(entry.Target, f.user) {
+		return false
+	}
+	if f.action != "" && entry.Action != f.action {
+		return false
+	}
+	if !f.from.IsZero() && entry.Timestamp.Before(f.from) {
+		return false
+	}
+	return f.to.IsZero() || entry.Timestamp.Before(f.to)
+}
+
+// audit answers /audit with the latest admin actions matching the filters in arguments.
+func audit(arguments, lang string) string {
+	location := database.ClubLocation()
+	filter, ok := parseAuditFilter(arguments, location)
+	if !ok {
+		return i18n.T(lang, auditUsage, strings.Join(database.AuditActions, ", "))
+	}
+
+	var entries []database.AuditEntry
+	for _, entry := range database.AuditLog() {
+		if filter.match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return i18n.T(lang, "No admin actions found.")
+	}
+
+	result := i18n.T(lang, "Admin actions, newest first:") + "\n"
+	for _, entry := range entries[:min(len(entries), auditLimit)] {
+		result += "\n" + entry.Timestamp.In(location).Format("02.01.2006 15:04") + " @" + entry.Actor + ": " + entry.Describe(lang)
+	}
+	if len(entries) > auditLimit {
+		result += "\n\n" + i18n.T(lang, "Showing %d of %d. Narrow it down with filters, see /audit help.", auditLimit, len(entries))
+	}
+	return result
+}
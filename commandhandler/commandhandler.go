@@ -4,18 +4,57 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"telegram-bot/database"
+	"telegram-bot/gamification"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/addnote"
+	"telegram-bot/statefunctions/addquote"
+	"telegram-bot/statefunctions/queuebook"
+	"telegram-bot/statefunctions/ratebook"
+	"telegram-bot/statefunctions/shelf"
 	"telegram-bot/statemachine"
+	"telegram-bot/utils"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// adminCommands are only available to club admins.
+var adminCommands = map[string]bool{
+	"setBookInfo":     true,
+	"addMeeting":      true,
+	"setClubTimezone": true,
+	"setActiveBook":   true,
+	"queueBook":       true,
+	"reorderQueue":    true,
+	"unqueueBook":     true,
+	"addMilestone":    true,
+	"setClubLanguage": true,
+	"export":          true,
+	"audit":           true,
+	"undo":            true,
+}
+
+// HandleCallback processes presses of inline keyboard buttons.
+func HandleCallback(bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	query := update.CallbackQuery
+	log.Printf("Received callback: %s", query.Data)
+
+	if data, ok := strings.CutPrefix(query.Data, "rsvp:"); ok {
+		handleRSVP(bot, query, data)
+		return
+	}
+	bot.Request(tgbotapi.NewCallback(query.ID, ""))
+}
+
 func HandleCommand(bot *tgbotapi.BotAPI, update tgbotapi.Update, username string) {
 	log.Printf("Received message: %s", update.Message.Text)
 	log.Printf("Command: %s", update.Message.Command())
 	log.Printf("Arguments: %s", update.Message.CommandArguments())
 
 	msg := tgbotapi.NewMessage(update.Message.Chat.ID, "")
+	lang := database.UserLanguage(username)
 
 	userStatus := database.UserStatus(username)
 	fmt.Println("User status: ", userStatus)
@@ -25,22 +64,68 @@ func HandleCommand(bot *tgbotapi.BotAPI, update tgbotapi.Update, username string
 	}
 
 	isUserAdmin := database.IsUserAdmin(username)
+	if adminCommands[update.Message.Command()] && !isUserAdmin {
+		msg.Text = i18n.T(lang, "You are not authorized to use this command.")
+		bot.Send(msg)
+		return
+	}
 
 	switch update.Message.Command() {
 	case "help":
-		msg.Text = help(isUserAdmin)
+		msg.Text = help(lang, isUserAdmin)
 	case "addBook":
 		statemachine.SetBook(username, "", bot, update)
 		return
+	case "setBookInfo":
+		statemachine.SetBookInfo(username, "", bot, update)
+		return
+	case "addMeeting":
+		statemachine.AddMeeting(username, "", bot, update)
+		return
+	case "meetings":
+		sendMeetings(bot, update.Message.Chat.ID, lang)
+		return
+	case "attendees":
+		msg.Text = attendees(lang)
+	case "calendar":
+		sendCalendar(bot, update.Message.Chat.ID, lang)
+		return
+	case "export":
+		sendExport(bot, update.Message.Chat.ID, lang)
+		return
+	case "import":
+		statemachine.Import(username, "", bot, update)
+		return
+	case "undo":
+		statemachine.Undo(username, "", bot, update)
+		return
+	case "setClubTimezone":
+		statemachine.SetClubTimezone(username, "", bot, update)
+		return
+	case "timezone":
+		statemachine.SetUserTimezone(username, "", bot, update)
+		return
 	case "getUserList":
-		msg.Text = getUserList()
+		msg.Text = getUserList(lang)
 	case "setProgress":
 		statemachine.SetProgress(username, "", bot, update)
 		return
+	case "setTotalPages":
+		statemachine.SetTotalPages(username, "", bot, update)
+		return
+	case "chapter":
+		statemachine.SetChapter(username, "", bot, update)
+		return
+	case "addMilestone":
+		statemachine.AddMilestone(username, "", bot, update)
+		return
+	case "changeFormat":
+		statemachine.ChangeFormat(username, "", bot, update)
+		return
 	case "getCurrentBook":
-		msg.Text = getCurrentBook()
+		msg.Text = getCurrentBook(username, lang)
 	case "getGroupProgress":
-		msg.Text = getGroupProgress()
+		msg.Text = getGroupProgress(lang)
 	// case "removeBook":
 	// 	msg.Text = removeBook(update.Message.CommandArguments(), isUserAdmin)
 	case "addUser":
@@ -50,12 +135,65 @@ func HandleCommand(bot *tgbotapi.BotAPI, update tgbotapi.Update, username string
 		statemachine.RemoveUser(username, "", bot, update)
 		return
 	case "getBookList":
-		msg.Text = bookList()
+		msg.Text = bookList(lang)
+	case "setActiveBook":
+		statemachine.SetActiveBook(username, "", bot, update)
+		return
+	case "queueBook":
+		statemachine.QueueBook(username, "", bot, update)
+		return
+	case "reorderQueue":
+		statemachine.ReorderQueue(username, "", bot, update)
+		return
+	case "unqueueBook":
+		statemachine.UnqueueBook(username, "", bot, update)
+		return
+	case "upcoming":
+		msg.Text = queuebook.FormatQueue(database.QueuedBooks(), lang)
+	case "addQuestion", "addNote":
+		statemachine.AddNote(username, "", bot, update)
+		return
+	case "questions":
+		msg.Text = addnote.FormatNotes(username, lang)
+		msg.ParseMode = tgbotapi.ModeHTML
+	case "quote":
+		statemachine.AddQuote(username, "", bot, update)
+		return
+	case "quotes":
+		msg.Text = addquote.Quotes(update.Message.CommandArguments(), lang)
+	case "rate":
+		statemachine.RateBook(username, "", bot, update)
+		return
+	case "me":
+		statemachine.Profile(username, "", bot, update)
+		return
+	case "shelf":
+		msg.Text = shelf.FormatShelf(username, lang)
+	case "addToShelf":
+		statemachine.AddToShelf(username, "", bot, update)
+		return
+	case "updateShelf":
+		statemachine.UpdateShelf(username, "", bot, update)
+		return
+	case "nominations":
+		msg.Text = shelf.FormatNominations(lang)
+	case "leaderboard":
+		msg.Text = gamification.Leaderboard(lang)
+	case "language":
+		statemachine.SetLanguage(username, "", bot, update)
+		return
+	case "setClubLanguage":
+		statemachine.SetClubLanguage(username, "", bot, update)
+		return
+	case "history":
+		msg.Text = history(update.Message.CommandArguments(), lang)
+	case "audit":
+		msg.Text = audit(update.Message.CommandArguments(), lang)
 	case "updateMeetingDate":
 		statemachine.UpdateMeetingDate(username, "", bot, update)
 		return
 	default:
-		msg.Text = "I don't recognize that command. Use /help to see the list of commands."
+		msg.Text = i18n.T(lang, "I don't recognize that command. Use /help to see the list of commands.")
 	}
 
 	if _, err := bot.Send(msg); err != nil {
@@ -63,36 +201,79 @@ func HandleCommand(bot *tgbotapi.BotAPI, update tgbotapi.Update, username string
 	}
 }
 
-func help(isUserAdmin bool) string {
+func help(lang string, isUserAdmin bool) string {
 	applicationVersion := "0.6"
 	if isUserAdmin {
-		return "Here are the commands you can use: \n/help\n/addBook\n/getUserList\n/setProgress\n/getCurrentBook\n/getGroupProgress\n/addUser\n/removeUser\n/getBookList\n/updateMeetingDate\n applicationVersion: " + applicationVersion
+		return i18n.T(lang, "Here are the commands you can use: ") + "\n/help\n/me\n/shelf\n/addToShelf\n/updateShelf\n/nominations\n/addBook\n/setBookInfo\n/getUserList\n/setProgress\n/chapter\n/changeFormat\n/setTotalPages\n/getCurrentBook\n/getGroupProgress\n/questions\n/addQuestion\n/addNote\n/quote\n/quotes\n/addUser\n/removeUser\n/getBookList\n/setActiveBook\n/history\n/leaderboard\n/rate\n/upcoming\n/queueBook\n/reorderQueue\n/unqueueBook\n/updateMeetingDate\n/addMilestone\n/addMeeting\n/meetings\n/attendees\n/calendar\n/timezone\n/language\n/setClubTimezone\n/setClubLanguage\n/export\n/import\n/audit\n/undo\n applicationVersion: " + applicationVersion
 	}
-	return "Here are the commands you can use: \n/help\n/setProgress\n/getCurrentBook\n/getGroupProgress"
+	return i18n.T(lang, "Here are the commands you can use: ") + "\n/help\n/me\n/shelf\n/addToShelf\n/updateShelf\n/nominations\n/setProgress\n/chapter\n/changeFormat\n/setTotalPages\n/getCurrentBook\n/getGroupProgress\n/questions\n/addQuestion\n/addNote\n/quote\n/quotes\n/meetings\n/attendees\n/calendar\n/history\n/leaderboard\n/rate\n/upcoming\n/timezone\n/language\n/import"
 }
 
-func getUserList() string {
+func getUserList(lang string) string {
 	userList := database.UserList()
 	usersText := "\n"
 	for _, user := range userList {
 		usersText += user.UserName + " : " + user.FullName + "\n"
 	}
 
-	return "Here is the list of users: " + usersText
+	return i18n.T(lang, "Here is the list of users: ") + usersText
 }
 
-func getCurrentBook() string {
+func getCurrentBook(username, lang string) string {
 	book := database.GetCurrentBook()
-	result := "The current book is: " + book.Title + " by " + book.Author + " (id - " + book.BookID + ") \n"
+	result := i18n.T(lang, "The current book is: %s by %s (id - %s) ", book.Title, book.Author, book.ShortID()) + "\n"
 	if book.MeetingDate != "" {
-		result += "Meeting date is " + book.MeetingDate + "\n"
+		result += i18n.T(lang, "Meeting date is %s", book.MeetingDate)
+		meetingDate, err := utils.ParseDate(book.MeetingDate, database.ClubLocation())
+		if err == nil {
+			daysLeft := utils.DaysBetween(time.Now().In(database.UserLocation(username)), meetingDate)
+			switch {
+			case daysLeft == 0:
+				result += i18n.T(lang, " (today)")
+			case daysLeft == 1:
+				result += i18n.T(lang, " (tomorrow)")
+			case daysLeft > 0:
+				result += " (" + i18n.N(lang, daysLeft, "in %d day", "in %d days", daysLeft) + ")"
+			}
+		}
+		result += "\n"
+	}
+	for _, milestone := range book.Milestones {
+		result += i18n.T(lang, "Milestone: %s by %s", milestone.Title, milestone.Deadline) + "\n"
+	}
+	if len(book.Chapters) > 0 {
+		result += i18n.T(lang, "Chapters: %d (report with /chapter)", len(book.Chapters)) + "\n"
+	}
+	if book.Genre != "" {
+		result += i18n.T(lang, "Genre: ") + book.Genre + "\n"
+	}
+	if book.Year != 0 {
+		result += i18n.T(lang, "Year: ") + strconv.Itoa(book.Year) + "\n"
+	}
+	if book.ISBN != "" {
+		result += "ISBN: " + book.ISBN + "\n"
+	}
+	for _, edition := range book.Editions {
+		result += i18n.T(lang, "Edition: ") + edition.Label(lang) + "\n"
+	}
+	if book.AudioMinutes != 0 {
+		result += i18n.T(lang, "Audiobook: ") + utils.FormatDuration(book.AudioMinutes) + "\n"
+	}
+	if rating := ratebook.FormatRating(database.BookRatings(book.BookID), lang); rating != "" {
+		result += i18n.T(lang, "Club rating: ") + rating + "\n"
+	}
+	if book.Description != "" {
+		result += "\n" + book.Description + "\n"
+	}
+	if book.CoverURL != "" {
+		result += "\n" + i18n.T(lang, "Cover: ") + book.CoverURL + "\n"
 	}
 
 	return result
 }
 
-func getGroupProgress() string {
-	return database.GroupProgress()
+func getGroupProgress(lang string) string {
+	return database.GroupProgress(lang)
 }
 
 // func removeBook(BookID string, isUserAdmin bool) string {
@@ -103,12 +284,18 @@ func getGroupProgress() string {
 // 	return "Done"
 // }
 
-func bookList() string {
+func bookList(lang string) string {
 	bookList := database.BookList()
 	booksText := "\n"
 	for _, book := range bookList {
-		booksText += book.Title + " by " + book.Author + "(id - " + book.BookID + "; active - " + strconv.FormatBool(book.Active) + ")\n"
+		booksText += "#" + book.ShortID() + " " + i18n.T(lang, "%s by %s", book.Title, book.Author)
+		if book.Active {
+			booksText += i18n.T(lang, " (current)")
+		} else if book.Queued {
+			booksText += i18n.T(lang, " (queued)")
+		}
+		booksText += "\n"
 	}
 
-	return "Here is the list of books: " + booksText
+	return i18n.T(lang, "Here is the list of books: ") + booksText
 }
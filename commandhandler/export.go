@@ -0,0 +1,25 @@
+package commandhandler
+
+import (
+	"log"
+	"telegram-bot/export"
+	"telegram-bot/i18n"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// sendExport sends the club's data as a ZIP of CSV and JSON files.
+func sendExport(bot *tgbotapi.BotAPI, chatID int64, lang string) {
+	data, err := export.Archive()
+	if err != nil {
+		log.Printf("Failed to build export: %s", err)
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "Sorry, the export failed. Please try again later.")))
+		return
+	}
+	document := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: export.FileName(time.Now()), Bytes: data})
+	document.Caption = i18n.T(lang, "Members, books, meetings, progress history, ratings and quotes as CSV and JSON.")
+	if _, err := bot.Send(document); err != nil {
+		log.Printf("Error sending export: %s", err)
+	}
+}
@@ -0,0 +1,120 @@
+package commandhandler
+
+import (
+	"fmt"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/addquote"
+	"telegram-bot/statefunctions/ratebook"
+	"time"
+	"unicode/utf8"
+)
+
+// historyLimit is how many of the latest books /history lists, so that the answer
+// fits in one Telegram message.
+const historyLimit = 15
+
+// messageBudget leaves room under Telegram's limit of 4096 characters.
+const messageBudget = 3800
+
+// pastBooks returns the books the club has finished, oldest first.
+func pastBooks() []database.Book {
+	var books []database.Book
+	for _, book := range database.BookList() {
+		if !book.Active && !book.Queued {
+			books = append(books, book)
+		}
+	}
+	return books
+}
+
+func history(arguments, lang string) string {
+	books := pastBooks()
+	if len(books) == 0 {
+		return i18n.T(lang, "The club hasn't finished any books yet.")
+	}
+	if arguments = strings.TrimSpace(arguments); arguments != "" {
+		if id, ok := database.ParseBookChoice(arguments); ok {
+			arguments = id
+		}
+		matches := database.FindBook(arguments)
+		if len(matches) != 1 {
+			return i18n.T(lang, "Please enter the ID or the title of one book, e.g. /history %s", books[0].ShortID())
+		}
+		return pastBook(matches[0], lang)
+	}
+
+	location := database.ClubLocation()
+	result := i18n.T(lang, "Books the club has read:") + "\n"
+	for _, book := range books[max(len(books)-historyLimit, 0):] {
+		progresses := database.BookProgress(book.BookID)
+		result += "\n#" + book.ShortID() + " " + i18n.T(lang, "%s by %s", book.Title, book.Author) + "\n"
+		result += "   " + activePeriod(book, location)
+		if book.MeetingDate != "" {
+			result += i18n.T(lang, ", meeting %s", book.MeetingDate)
+		}
+		result += "\n   " + i18n.T(lang, "Finished: %d of %d members", finishedCount(progresses), len(progresses)) + "\n"
+		if rating := ratebook.FormatRating(database.BookRatings(book.BookID), lang); rating != "" {
+			result += "   " + i18n.T(lang, "Rating: ") + rating + "\n"
+		}
+	}
+	if len(books) > historyLimit {
+		result += "\n" + i18n.T(lang, "Showing the last %d of %d books. Use /history <book ID> for any other, the IDs are in /getBookList.", historyLimit, len(books))
+	}
+	result += "\n" + i18n.T(lang, "Use /history <book ID> to see the final group progress of a book.")
+	return result
+}
+
+func pastBook(book database.Book, lang string) string {
+	progresses := database.BookProgress(book.BookID)
+	result := i18n.T(lang, "%s by %s", book.Title, book.Author) + "\n"
+	result += activePeriod(book, database.ClubLocation()) + "\n"
+	if book.MeetingDate != "" {
+		result += i18n.T(lang, "Meeting date was %s", book.MeetingDate) + "\n"
+	}
+	result += i18n.T(lang, "Finished: %d of %d members", finishedCount(progresses), len(progresses)) + "\n"
+	ratings := database.BookRatings(book.BookID)
+	if rating := ratebook.FormatRating(ratings, lang); rating != "" {
+		result += i18n.T(lang, "Rating: ") + rating + "\n"
+	}
+	result += "\n" + database.FormatGroupProgress(progresses, lang)
+	for _, rating := range ratings {
+		if rating.Review != "" {
+			result += fmt.Sprintf("\n%s (%d/5): %s", rating.UserName, rating.Rating, rating.Review)
+		}
+	}
+	if quotes := database.BookQuotes(book.BookID); len(quotes) > 0 {
+		result += "\n\n" + i18n.T(lang, "Quotes:") + "\n\n"
+		if budget := messageBudget - utf8.RuneCountInString(result); budget > 0 {
+			result += addquote.LatestQuotes(quotes, lang, budget)
+		} else {
+			result += i18n.T(lang, "See them with /quotes %s.", book.ShortID())
+		}
+	}
+	return result
+}
+
+func activePeriod(book database.Book, location *time.Location) string {
+	period := "?"
+	if started := book.Started(); !started.IsZero() {
+		period = started.In(location).Format("02.01.2006")
+	}
+	period += " - "
+	if !book.FinishedAt.IsZero() {
+		period += book.FinishedAt.In(location).Format("02.01.2006")
+	} else {
+		period += "?"
+	}
+	return period
+}
+
+func finishedCount(progresses []database.ReadingProgress) int {
+	finished := 0
+	for _, progress := range progresses {
+		if progress.Progress >= 100 {
+			finished++
+		}
+	}
+	return finished
+}
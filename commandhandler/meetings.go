@@ -0,0 +1,146 @@
+package commandhandler
+
+import (
+	"log"
+	"strings"
+	"telegram-bot/calendar"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var rsvpLabels = map[database.RSVPStatus]string{
+	database.Going:    "Going",
+	database.Maybe:    "Maybe",
+	database.NotGoing: "Not going",
+}
+
+// upcomingMeetings returns the current book's meetings that haven't started yet.
+func upcomingMeetings() []database.Meeting {
+	var upcoming []database.Meeting
+	for _, meeting := range database.BookMeetings(database.GetCurrentBook()) {
+		start, err := meeting.Start()
+		if err != nil || start.AddDate(0, 0, 1).Before(time.Now()) {
+			continue
+		}
+		upcoming = append(upcoming, meeting)
+	}
+	return upcoming
+}
+
+func sendMeetings(bot *tgbotapi.BotAPI, chatID int64, lang string) {
+	meetings := upcomingMeetings()
+	if len(meetings) == 0 {
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "There are no upcoming meetings.")))
+		return
+	}
+	for _, meeting := range meetings {
+		msg := tgbotapi.NewMessage(chatID, describeMeeting(meeting, lang))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, rsvpLabels[database.Going]), rsvpData(meeting.MeetingID, database.Going)),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, rsvpLabels[database.Maybe]), rsvpData(meeting.MeetingID, database.Maybe)),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, rsvpLabels[database.NotGoing]), rsvpData(meeting.MeetingID, database.NotGoing)),
+			),
+		)
+		if _, err := bot.Send(msg); err != nil {
+			log.Printf("Error sending message: %s", err)
+		}
+	}
+}
+
+func describeMeeting(meeting database.Meeting, lang string) string {
+	result := i18n.T(lang, meeting.Title) + " - " + meeting.Date
+	if meeting.Time != "" {
+		result += " " + meeting.Time
+	}
+	if meeting.Timezone != "" {
+		result += " (" + meeting.Timezone + ")"
+	}
+	result += "\n"
+	if meeting.Location != "" {
+		result += i18n.T(lang, "Place: ") + meeting.Location + "\n"
+	}
+	if meeting.Link != "" {
+		result += i18n.T(lang, "Link: ") + meeting.Link + "\n"
+	}
+	if meeting.Agenda != "" {
+		result += i18n.T(lang, "Agenda: ") + meeting.Agenda + "\n"
+	}
+	return result
+}
+
+func attendees(lang string) string {
+	meetings := upcomingMeetings()
+	if len(meetings) == 0 {
+		return i18n.T(lang, "There are no upcoming meetings.")
+	}
+	result := ""
+	for _, meeting := range meetings {
+		answers := map[database.RSVPStatus][]string{}
+		for _, rsvp := range database.MeetingRSVPs(meeting.MeetingID) {
+			user := database.GetUserDetails(rsvp.UserName)
+			answers[rsvp.Status] = append(answers[rsvp.Status], user.FullName)
+		}
+		result += i18n.T(lang, meeting.Title) + " - " + meeting.Date + "\n"
+		for _, status := range []database.RSVPStatus{database.Going, database.Maybe, database.NotGoing} {
+			if len(answers[status]) > 0 {
+				result += i18n.T(lang, rsvpLabels[status]) + ": " + strings.Join(answers[status], ", ") + "\n"
+			}
+		}
+		if len(answers) == 0 {
+			result += i18n.T(lang, "Nobody has answered yet.") + "\n"
+		}
+		result += "\n"
+	}
+	return result
+}
+
+// sendCalendar sends the next meeting as an .ics file, plus the feed URL to subscribe to.
+func sendCalendar(bot *tgbotapi.BotAPI, chatID int64, lang string) {
+	meetings := upcomingMeetings()
+	if len(meetings) == 0 {
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "There are no upcoming meetings.")))
+		return
+	}
+	books := map[string]database.Book{}
+	for _, book := range database.BookList() {
+		books[book.BookID] = book
+	}
+
+	file := tgbotapi.FileBytes{Name: "meeting.ics", Bytes: calendar.ICS(meetings[:1], books)}
+	document := tgbotapi.NewDocument(chatID, file)
+	document.Caption = i18n.T(lang, meetings[0].Title) + " - " + meetings[0].Date
+	if _, err := bot.Send(document); err != nil {
+		log.Printf("Error sending calendar: %s", err)
+	}
+
+	if feedURL := calendar.FeedURL(); feedURL != "" {
+		bot.Send(tgbotapi.NewMessage(chatID, i18n.T(lang, "Subscribe to all club meetings: ")+feedURL))
+	}
+}
+
+func rsvpData(meetingID string, status database.RSVPStatus) string {
+	return "rsvp:" + meetingID + ":" + string(status)
+}
+
+func handleRSVP(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) {
+	i := strings.LastIndex(data, ":")
+	label, ok := "", false
+	if i >= 0 {
+		label, ok = rsvpLabels[database.RSVPStatus(data[i+1:])]
+	}
+	if !ok {
+		// Answer anyway, or the button keeps spinning in the client.
+		bot.Request(tgbotapi.NewCallback(query.ID, ""))
+		return
+	}
+	meetingID, status := data[:i], database.RSVPStatus(data[i+1:])
+	database.SetRSVP(meetingID, query.From.UserName, status)
+	lang := database.UserLanguage(query.From.UserName)
+	if _, err := bot.Request(tgbotapi.NewCallback(query.ID, i18n.T(lang, "Your answer: ")+i18n.T(lang, label))); err != nil {
+		log.Printf("Error answering callback: %s", err)
+	}
+}
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"telegram-bot/i18n"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// Audited admin actions.
+const (
+	ActionAddBook        = "AddBook"
+	ActionRemoveUser     = "RemoveUser"
+	ActionUpdateBookDate = "UpdateBookDate"
+	ActionAddUser        = "AddUser"
+	ActionUndo           = "Undo"
+)
+
+// AuditActions lists the audited actions for /audit.
+var AuditActions = []string{ActionAddBook, ActionRemoveUser, ActionUpdateBookDate, ActionAddUser, ActionUndo}
+
+// AuditEntry records one admin action. Entries are only ever added, never changed.
+type AuditEntry struct {
+	// EventID is the time of the action in nanoseconds, unique and sortable.
+	EventID   string    `dynamodbav:"EventID"`
+	Timestamp time.Time `dynamodbav:"Timestamp"`
+	Actor     string    `dynamodbav:"Actor"`
+	Action    string    `dynamodbav:"Action"`
+	// Target is the nickname or the book ID the action was about.
+	Target string `dynamodbav:"Target"`
+	// Before and After hold the affected record as JSON, empty if it didn't exist.
+	Before string `dynamodbav:"Before"`
+	After  string `dynamodbav:"After"`
+	// Undoes is the EventID of the entry an Undo reverted, UndoneAction its action.
+	Undoes       string `dynamodbav:"Undoes"`
+	UndoneAction string `dynamodbav:"UndoneAction"`
+}
+
+// RecordAudit appends an entry to the audit log. before and after are the affected
+// record, e.g. a User or a Book, or nil if there was none.
+func RecordAudit(actor, action, target string, before, after interface{}) {
+	putAuditEntry(AuditEntry{
+		Actor:  actor,
+		Action: action,
+		Target: target,
+		Before: auditJSON(before),
+		After:  auditJSON(after),
+	})
+}
+
+// RecordUndo appends an Undo of entry to the audit log. Its records are those
+// of entry swapped, as the undo changed them back.
+func RecordUndo(actor string, entry AuditEntry) {
+	putAuditEntry(AuditEntry{
+		Actor:        actor,
+		Action:       ActionUndo,
+		Target:       entry.Target,
+		Before:       entry.After,
+		After:        entry.Before,
+		Undoes:       entry.EventID,
+		UndoneAction: entry.Action,
+	})
+}
+
+func putAuditEntry(entry AuditEntry) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	now := time.Now().UTC()
+	entry.EventID = strconv.FormatInt(now.UnixNano(), 10)
+	entry.Timestamp = now
+	item, err := dynamodbattribute.MarshalMap(entry)
+	if err != nil {
+		log.Fatalf("Failed to marshal audit entry: %s", err)
+	}
+
+	auditTable := tableName("audit")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName:           aws.String(auditTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(EventID)"),
+	})
+	if err != nil {
+		log.Fatalf("Failed to put audit entry into DynamoDB: %s", err)
+	}
+	log.Printf("Audit: %s %s %s", entry.Actor, entry.Action, entry.Target)
+}
+
+func auditJSON(record interface{}) string {
+	if record == nil {
+		return ""
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Fatalf("Failed to marshal audited record: %s", err)
+	}
+	return string(data)
+}
+
+// AuditLog returns every entry of the audit log, newest first.
+func AuditLog() []AuditEntry {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	auditTable := tableName("audit")
+	var entries []AuditEntry
+	err := svc.ScanPages(&dynamodb.ScanInput{
+		TableName: aws.String(auditTable),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		var pageEntries []AuditEntry
+		if err := dynamodbattribute.UnmarshalListOfMaps(page.Items, &pageEntries); err != nil {
+			log.Fatalf("Failed to unmarshal audit log: %s", err)
+		}
+		entries = append(entries, pageEntries...)
+		return true
+	})
+	if err != nil {
+		log.Fatalf("Failed to scan audit log: %s", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+	return entries
+}
+
+// Records decodes Before and After into before and after, leaving them unchanged
+// where the entry has no record.
+func (e AuditEntry) Records(before, after interface{}) {
+	for _, r := range []struct {
+		data   string
+		record interface{}
+	}{{e.Before, before}, {e.After, after}} {
+		if r.data == "" || r.record == nil {
+			continue
+		}
+		if err := json.Unmarshal([]byte(r.data), r.record); err != nil {
+			log.Printf("Failed to unmarshal audited record of %s: %s", e.EventID, err)
+		}
+	}
+}
+
+// Describe tells what the action changed, with the values before and after.
+func (e AuditEntry) Describe(lang string) string {
+	switch e.Action {
+	case ActionAddBook:
+		var before, after Book
+		e.Records(&before, &after)
+		if before.BookID == "" {
+			return i18n.T(lang, "added the book \"%s\"", after.Title)
+		}
+		return i18n.T(lang, "added the book \"%s\", replacing \"%s\"", after.Title, before.Title)
+	case ActionUpdateBookDate:
+		var before, after Book
+		e.Records(&before, &after)
+		previous := before.MeetingDate
+		if previous == "" {
+			previous = "-"
+		}
+		return i18n.T(lang, "changed the meeting date of \"%s\": %s → %s", before.Title, previous, after.MeetingDate)
+	case ActionAddUser:
+		var after User
+		e.Records(nil, &after)
+		if after.FullName != "" && after.FullName != after.UserName {
+			return i18n.T(lang, "added @%s (%s)", e.Target, after.FullName)
+		}
+		return i18n.T(lang, "added @%s", e.Target)
+	case ActionRemoveUser:
+		var before User
+		e.Records(&before, nil)
+		if before.UserName == "" {
+			return i18n.T(lang, "tried to remove @%s, who was not a member", e.Target)
+		}
+		if before.FullName == "" {
+			return i18n.T(lang, "removed @%s", e.Target)
+		}
+		return i18n.T(lang, "removed @%s (%s)", e.Target, before.FullName)
+	case ActionUndo:
+		undone := AuditEntry{Action: e.UndoneAction, Target: e.Target, Before: e.After, After: e.Before}
+		return i18n.T(lang, "undid: %s", undone.Describe(lang))
+	}
+	return e.Action + " " + e.Target
+}
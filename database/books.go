@@ -0,0 +1,297 @@
+package database
+
+import (
+	"log"
+	"strings"
+	"telegram-bot/utils"
+	"time"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+// NextBookID returns the next sequential book ID ("1", "2", ...) from a counter
+// kept in the club settings.
+func NextBookID() string {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	settingsTable := tableName("settings")
+	result, err := svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(settingsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ClubID": {
+				S: aws.String(clubSettingsID),
+			},
+		},
+		UpdateExpression: aws.String("add BookCounter :one"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":one": {
+				N: aws.String("1"),
+			},
+		},
+		ReturnValues: aws.String("UPDATED_NEW"),
+	})
+	if err != nil {
+		log.Fatalf("Failed to increment book counter: %s", err)
+	}
+
+	return aws.StringValue(result.Attributes["BookCounter"].N)
+}
+
+// AddPastBook stores a book the club read before it used the bot, e.g. from an import.
+// It is neither active nor queued, so it shows up in /history.
+func AddPastBook(book Book) string {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	book.BookID = NextBookID()
+	book.Active = false
+	book.Queued = false
+	// Keep the book in the club's reading order, see Book.Started.
+	if book.StartedAt.IsZero() {
+		book.StartedAt = book.FinishedAt
+	}
+	if book.StartedAt.IsZero() && book.MeetingDate != "" {
+		if date, err := utils.ParseDate(book.MeetingDate, ClubLocation()); err == nil {
+			book.StartedAt = date.UTC()
+		}
+	}
+	item, err := dynamodbattribute.MarshalMap(book)
+	if err != nil {
+		log.Fatalf("Got error marshalling past book item: %s", err)
+	}
+
+	booksTable := tableName("books")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(booksTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Got error calling PutItem: %s", err)
+	}
+
+	log.Printf("Successfully added past book '%s'", book.Title)
+	return book.BookID
+}
+
+// Slug is a typeable identifier derived from the title, e.g. "the-master-and-margarita".
+func (b Book) Slug() string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(b.Title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && slug.Len() > 0 {
+				slug.WriteRune('-')
+			}
+			slug.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return slug.String()
+}
+
+// ShortID is the identifier shown to members: the sequential ID, or the slug
+// for books created when IDs were nanosecond timestamps.
+func (b Book) ShortID() string {
+	if len(b.BookID) <= 6 {
+		return b.BookID
+	}
+	return b.Slug()
+}
+
+func GetBook(bookID string) Book {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	booksTable := tableName("books")
+	result, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(booksTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"BookID": {
+				S: aws.String(bookID),
+			},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to get book '%s': %s", bookID, err)
+	}
+
+	var book Book
+	err = dynamodbattribute.UnmarshalMap(result.Item, &book)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal DynamoDB item to Book: %s", err)
+	}
+
+	return book
+}
+
+// FindBook looks a book up by ID, slug or title. An exact match is returned alone;
+// otherwise all books whose title contains the query, or failing that is close to it.
+func FindBook(query string) []Book {
+	query = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(query), "#")))
+	if query == "" {
+		return nil
+	}
+	books := BookList()
+	for _, book := range books {
+		if book.BookID == query || book.Slug() == query || strings.ToLower(book.Title) == query {
+			return []Book{book}
+		}
+	}
+
+	var matches []Book
+	for _, book := range books {
+		if strings.Contains(strings.ToLower(book.Title), query) {
+			matches = append(matches, book)
+		}
+	}
+	if len(matches) > 0 {
+		return matches
+	}
+
+	// Allow roughly one typo per four letters
+	for _, book := range books {
+		title := strings.ToLower(book.Title)
+		if levenshtein(title, query) <= len([]rune(title))/4 {
+			matches = append(matches, book)
+		}
+	}
+	return matches
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+// SetActiveBook makes an existing book the club's current book. Queued books
+// leave the queue and start now; past books keep their original start.
+func SetActiveBook(bookID string) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	book := GetBook(bookID)
+	deactivateBooks(svc)
+
+	updateExpression := "set Active = :val, Queued = :queued remove FinishedAt"
+	values := map[string]*dynamodb.AttributeValue{
+		":val": {
+			BOOL: aws.Bool(true),
+		},
+		":queued": {
+			BOOL: aws.Bool(false),
+		},
+	}
+	if book.Started().IsZero() {
+		updateExpression = "set Active = :val, Queued = :queued, StartedAt = :started remove FinishedAt"
+		values[":started"] = &dynamodb.AttributeValue{S: aws.String(time.Now().UTC().Format(time.RFC3339))}
+	}
+
+	booksTable := tableName("books")
+	_, err := svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(booksTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"BookID": {
+				S: aws.String(bookID),
+			},
+		},
+		UpdateExpression:          aws.String(updateExpression),
+		ExpressionAttributeValues: values,
+	})
+	if err != nil {
+		log.Fatalf("Got error calling UpdateItem: %s", err)
+	}
+
+	log.Printf("Book '%s' is now active", bookID)
+}
+
+// deactivateBooks marks every active book as finished.
+func deactivateBooks(svc *dynamodb.DynamoDB) {
+	// Scan the table to find all books that are currently active
+	filt := expression.Name("Active").Equal(expression.Value(true))
+	expr, err := expression.NewBuilder().WithFilter(filt).Build()
+	if err != nil {
+		log.Fatalf("Got error building expression: %s", err)
+	}
+
+	booksTable := tableName("books")
+	scanInput := &dynamodb.ScanInput{
+		TableName:                 aws.String(booksTable),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+	}
+
+	result, err := svc.Scan(scanInput)
+	if err != nil {
+		log.Fatalf("Query API call failed: %s", err)
+	}
+
+	// Update each active book to be inactive
+	for _, item := range result.Items {
+		var book Book
+		err = dynamodbattribute.UnmarshalMap(item, &book)
+		if err != nil {
+			log.Fatalf("Failed to unmarshal Book, %v", err)
+		}
+
+		updateInput := &dynamodb.UpdateItemInput{
+			TableName: aws.String(booksTable),
+			Key: map[string]*dynamodb.AttributeValue{
+				"BookID": {
+					S: aws.String(book.BookID),
+				},
+			},
+			UpdateExpression: aws.String("set Active = :val, FinishedAt = :finished"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":val": {
+					BOOL: aws.Bool(false),
+				},
+				":finished": {
+					S: aws.String(time.Now().UTC().Format(time.RFC3339)),
+				},
+			},
+		}
+
+		_, err = svc.UpdateItem(updateInput)
+		if err != nil {
+			log.Fatalf("Got error calling UpdateItem: %s", err)
+		}
+	}
+}
+
+// FormatBookChoice is the keyboard label used when several books match a search.
+func FormatBookChoice(book Book) string {
+	return "#" + book.ShortID() + " " + book.Title
+}
+
+// ParseBookChoice reverses FormatBookChoice.
+func ParseBookChoice(label string) (string, bool) {
+	if !strings.HasPrefix(label, "#") {
+		return "", false
+	}
+	id, _, _ := strings.Cut(label[1:], " ")
+	return id, id != ""
+}
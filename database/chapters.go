@@ -0,0 +1,87 @@
+package database
+
+import (
+	"sort"
+	"strings"
+	"telegram-bot/utils"
+	"time"
+)
+
+// Chapter is a chapter of the book and the page it starts on in the book's first edition.
+type Chapter struct {
+	Name      string `dynamodbav:"Name"`
+	StartPage int    `dynamodbav:"StartPage"`
+}
+
+// Milestone is an intermediate goal such as "read to chapter 12 by next week".
+type Milestone struct {
+	Title    string `dynamodbav:"Title"`
+	Percent  int    `dynamodbav:"Percent"`
+	Deadline string `dynamodbav:"Deadline"` // dd.mm.yyyy
+	Reminded bool   `dynamodbav:"Reminded"`
+}
+
+// referencePages is the page count chapter start pages refer to: the first edition,
+// or the start of the last chapter if no edition is known.
+func (b Book) referencePages() int {
+	if len(b.Editions) > 0 {
+		return b.Editions[0].Pages
+	}
+	if len(b.Chapters) > 0 {
+		return b.Chapters[len(b.Chapters)-1].StartPage
+	}
+	return 0
+}
+
+// ChapterEnd returns the percent of the book read once chapter i is finished.
+func (b Book) ChapterEnd(i int) int {
+	if i+1 >= len(b.Chapters) {
+		return 100
+	}
+	return utils.Percent(b.Chapters[i+1].StartPage-1, b.referencePages())
+}
+
+// FindChapter looks up a chapter by name, ignoring case.
+func (b Book) FindChapter(name string) (int, bool) {
+	for i, chapter := range b.Chapters {
+		if strings.EqualFold(chapter.Name, strings.TrimSpace(name)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// NextMilestone returns the first milestone whose deadline is today or later.
+func (b Book) NextMilestone(today time.Time) (Milestone, bool) {
+	for _, milestone := range b.Milestones {
+		deadline, err := utils.ParseDate(milestone.Deadline, today.Location())
+		if err == nil && utils.DaysBetween(today, deadline) >= 0 {
+			return milestone, true
+		}
+	}
+	return Milestone{}, false
+}
+
+// SortMilestones orders milestones by deadline.
+func SortMilestones(milestones []Milestone) {
+	sort.SliceStable(milestones, func(i, j int) bool {
+		a, _ := utils.ParseDate(milestones[i].Deadline, time.UTC)
+		b, _ := utils.ParseDate(milestones[j].Deadline, time.UTC)
+		return a.Before(b)
+	})
+}
+
+// WithPercent moves the progress to percent, keeping the member's format.
+func (p ReadingProgress) WithPercent(percent int) ReadingProgress {
+	p.Progress = percent
+	switch p.Type {
+	case RegularBook:
+		return p.ConvertTo(p.Type, p.TotalPages)
+	case KindleBook:
+		return p.ConvertTo(p.Type, p.TotalLocations)
+	case AudioBook:
+		return p.ConvertTo(p.Type, p.TotalMinutes)
+	}
+	p.Type = EBook
+	return p
+}
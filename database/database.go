@@ -5,9 +5,13 @@ package database
 import (
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"sort"
 	"strconv"
+	"strings"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -23,14 +27,70 @@ type User struct {
 	FullName string `dynamodbav:"FullName"`
 	IsAdmin  bool   `dynamodbav:"IsAdmin"`
 	Status   string `dynamodbav:"Status"`
+	// Draft keeps the value chosen in a previous step of a multi-step dialog.
+	Draft    string `dynamodbav:"Draft"`
+	Timezone string `dynamodbav:"Timezone"`
+	// ChatID is the member's private chat with the bot, used for reminders.
+	ChatID int64 `dynamodbav:"ChatID"`
+	// Language is the member's choice from /language or /me. TelegramLanguage is the
+	// language of their Telegram app, only used if neither they nor the club chose one.
+	Language         string `dynamodbav:"Language"`
+	TelegramLanguage string `dynamodbav:"TelegramLanguage"`
+	// Reminder opt-outs, set from /me.
+	NoMilestoneReminders bool `dynamodbav:"NoMilestoneReminders"`
+	NoRatingRequests     bool `dynamodbav:"NoRatingRequests"`
 }
 
 type Book struct {
-	BookID      string `dynamodbav:"BookID"`
-	Title       string `dynamodbav:"Title"`
-	Author      string `dynamodbav:"Author"`
-	Active      bool   `dynamodbav:"Active"`
-	MeetingDate string `dynamodbav:"MeetingDate"`
+	BookID       string    `dynamodbav:"BookID"`
+	Title        string    `dynamodbav:"Title"`
+	Author       string    `dynamodbav:"Author"`
+	Active       bool      `dynamodbav:"Active"`
+	MeetingDate  string    `dynamodbav:"MeetingDate"`
+	ISBN         string    `dynamodbav:"ISBN"`
+	Editions     []Edition `dynamodbav:"Editions"`
+	AudioMinutes int       `dynamodbav:"AudioMinutes"`
+	Genre        string    `dynamodbav:"Genre"`
+	CoverURL     string    `dynamodbav:"CoverURL"`
+	Description  string    `dynamodbav:"Description"`
+	Year         int       `dynamodbav:"Year"`
+	StartedAt    time.Time `dynamodbav:"StartedAt"`
+	FinishedAt   time.Time `dynamodbav:"FinishedAt"`
+	// Queued books are planned to be read after the current one, in QueuePosition order.
+	Queued         bool        `dynamodbav:"Queued"`
+	QueuePosition  int         `dynamodbav:"QueuePosition"`
+	PlannedStart   string      `dynamodbav:"PlannedStart"`   // dd.mm.yyyy
+	PlannedMeeting string      `dynamodbav:"PlannedMeeting"` // dd.mm.yyyy
+	Chapters       []Chapter   `dynamodbav:"Chapters"`
+	Milestones     []Milestone `dynamodbav:"Milestones"`
+	// RatingsRequested is set once members have been asked to rate the book after the meeting.
+	RatingsRequested bool `dynamodbav:"RatingsRequested"`
+}
+
+// Started returns when the book became the club's book. Books added before
+// StartedAt was stored use the timestamp their ID was generated from.
+func (b Book) Started() time.Time {
+	if !b.StartedAt.IsZero() {
+		return b.StartedAt
+	}
+	if nanos, err := strconv.ParseInt(b.BookID, 10, 64); err == nil && nanos > 1e18 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
+}
+
+// Edition is a printed edition of a book with its page count.
+type Edition struct {
+	Name  string `dynamodbav:"Name"`
+	Pages int    `dynamodbav:"Pages"`
+}
+
+func (e Edition) Label(lang string) string {
+	pages := i18n.N(lang, e.Pages, "%d page", "%d pages", e.Pages)
+	if e.Name == "" {
+		return pages
+	}
+	return e.Name + " - " + pages
 }
 
 type BookType string
@@ -38,15 +98,107 @@ type BookType string
 const (
 	AudioBook   BookType = "audio"
 	RegularBook BookType = "regular"
+	EBook       BookType = "ebook"
+	KindleBook  BookType = "kindle"
 )
 
+// BookTypes lists the formats in the order they are offered to members.
+var BookTypes = []BookType{RegularBook, EBook, KindleBook, AudioBook}
+
 type ReadingProgress struct {
-	UserName   string   `dynamodbav:"UserName"`
-	BookID     string   `dynamodbav:"BookID"`
-	Progress   int      `dynamodbav:"Progress"`
-	Type       BookType `dynamodbav:"Type"`
-	TotalPages int      `dynamodbav:"TotalPages"`
-	PageNumber int      `dynamodbav:"PageNumber"`
+	UserName        string   `dynamodbav:"UserName"`
+	BookID          string   `dynamodbav:"BookID"`
+	Progress        int      `dynamodbav:"Progress"`
+	Type            BookType `dynamodbav:"Type"`
+	TotalPages      int      `dynamodbav:"TotalPages"`
+	PageNumber      int      `dynamodbav:"PageNumber"`
+	TotalLocations  int      `dynamodbav:"TotalLocations"`
+	Location        int      `dynamodbav:"Location"`
+	TotalMinutes    int      `dynamodbav:"TotalMinutes"`
+	ListenedMinutes int      `dynamodbav:"ListenedMinutes"`
+}
+
+// Position describes where the reader is in their own format, e.g. "page 120/300".
+// Progress always holds the same position normalised to percent.
+func (p ReadingProgress) Position(lang string) string {
+	switch p.Type {
+	case RegularBook:
+		if p.TotalPages > 0 {
+			return i18n.T(lang, "page %d/%d", p.PageNumber, p.TotalPages)
+		}
+	case KindleBook:
+		if p.TotalLocations > 0 {
+			return i18n.T(lang, "location %d/%d", p.Location, p.TotalLocations)
+		}
+	case AudioBook:
+		if p.TotalMinutes > 0 {
+			return fmt.Sprintf("%s/%s", utils.FormatDuration(p.ListenedMinutes), utils.FormatDuration(p.TotalMinutes))
+		}
+	}
+	return ""
+}
+
+// Total is the length of the book in the reader's format: pages, Kindle locations
+// or minutes. It is 0 for e-books and until the reader has entered it.
+func (p ReadingProgress) Total() int {
+	switch p.Type {
+	case RegularBook:
+		return p.TotalPages
+	case KindleBook:
+		return p.TotalLocations
+	case AudioBook:
+		return p.TotalMinutes
+	}
+	return 0
+}
+
+// moved reports whether p is a new reading position compared to previous, the
+// record it replaces. Corrected totals and format changes keep the position, so
+// they are not progress for streaks and badges.
+func (p ReadingProgress) moved(previous ReadingProgress) bool {
+	if p.Type != previous.Type {
+		return previous.Type == ""
+	}
+	switch p.Type {
+	case RegularBook:
+		return p.PageNumber != previous.PageNumber
+	case KindleBook:
+		return p.Location != previous.Location
+	case AudioBook:
+		return p.ListenedMinutes != previous.ListenedMinutes
+	}
+	return p.Progress != previous.Progress
+}
+
+// ConvertTo moves the progress to another format, keeping the same relative position.
+// total is the length of the book in the new format (pages, locations or minutes)
+// and is ignored for e-books.
+func (p ReadingProgress) ConvertTo(newType BookType, total int) ReadingProgress {
+	converted := ReadingProgress{UserName: p.UserName, BookID: p.BookID, Type: newType, Progress: p.Progress}
+	position := int(math.Round(float64(p.Progress) * float64(total) / 100))
+	switch newType {
+	case RegularBook:
+		converted.TotalPages = total
+		converted.PageNumber = position
+	case KindleBook:
+		converted.TotalLocations = total
+		converted.Location = position
+	case AudioBook:
+		converted.TotalMinutes = total
+		converted.ListenedMinutes = position
+	}
+	return converted
+}
+
+// WithTotalPages corrects the page count of the reader's edition. The page the member
+// reported stays as is (clamped to the new length) and Progress is recalculated from it.
+func (p ReadingProgress) WithTotalPages(total int) ReadingProgress {
+	p.TotalPages = total
+	if p.PageNumber > total {
+		p.PageNumber = total
+	}
+	p.Progress = utils.Percent(p.PageNumber, total)
+	return p
 }
 
 func AWSsession() *session.Session {
@@ -71,26 +223,77 @@ func tableName(table string) string {
 		log.Fatal("There is no environment")
 	}
 
-	type TableConfig = map[string]string
+	return TableName(table, environment)
+}
 
-	var tablesPerEnv = map[string]TableConfig{
-		"users": {
-			"prod": "Users",
-			"dev":  "Users_dev",
-		},
-		"books": {
-			"prod": "Books",
-			"dev":  "Books_dev",
-		},
-		"reading_progress": {
-			"prod": "ReadingProgress",
-			"dev":  "ReadingProgress_dev",
-		},
-	}
+// tablesPerEnv maps each table to its DynamoDB name in every environment.
+var tablesPerEnv = map[string]map[string]string{
+	"users": {
+		"prod": "Users",
+		"dev":  "Users_dev",
+	},
+	"books": {
+		"prod": "Books",
+		"dev":  "Books_dev",
+	},
+	"reading_progress": {
+		"prod": "ReadingProgress",
+		"dev":  "ReadingProgress_dev",
+	},
+	"meetings": {
+		"prod": "Meetings",
+		"dev":  "Meetings_dev",
+	},
+	"rsvps": {
+		"prod": "RSVPs",
+		"dev":  "RSVPs_dev",
+	},
+	"ratings": {
+		"prod": "Ratings",
+		"dev":  "Ratings_dev",
+	},
+	"notes": {
+		"prod": "Notes",
+		"dev":  "Notes_dev",
+	},
+	"quotes": {
+		"prod": "Quotes",
+		"dev":  "Quotes_dev",
+	},
+	"progress_history": {
+		"prod": "ProgressHistory",
+		"dev":  "ProgressHistory_dev",
+	},
+	"shelf": {
+		"prod": "Shelf",
+		"dev":  "Shelf_dev",
+	},
+	"settings": {
+		"prod": "Settings",
+		"dev":  "Settings_dev",
+	},
+	"audit": {
+		"prod": "AuditLog",
+		"dev":  "AuditLog_dev",
+	},
+}
 
+// TableName returns the DynamoDB name of table in environment ("prod" or "dev"),
+// or "" if there is no such table.
+func TableName(table, environment string) string {
 	return tablesPerEnv[table][environment]
 }
 
+// Tables lists every table the bot stores data in, in a stable order.
+func Tables() []string {
+	tables := make([]string, 0, len(tablesPerEnv))
+	for table := range tablesPerEnv {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
 func IsUserExists(username string) bool {
 	sess := AWSsession()
 	svc := dynamodb.New(sess)
@@ -189,26 +392,11 @@ func IsUserAdmin(username string) bool {
 }
 
 func UserList() []User {
-	sess := AWSsession()
-	svc := dynamodb.New(sess)
-
-	usersTable := tableName("users")
-	input := &dynamodb.ScanInput{
-		TableName: aws.String(usersTable),
-	}
-	result, err := svc.Scan(input)
+	users, err := FetchUsers()
 	if err != nil {
-		log.Fatalf("Query API call failed: %s", err)
+		log.Fatalf("Failed to list users: %s", err)
 	}
-
-	var userList []User
-
-	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &userList)
-	if err != nil {
-		log.Fatalf("Failed to unmarshal Query result items, %v", err)
-	}
-
-	return userList
+	return users
 }
 
 func IsUserBelongsToClub(username string) bool {
@@ -248,64 +436,16 @@ func AddBook(title string) {
 	svc := dynamodb.New(sess)
 
 	// Step 1: Make all existing books inactive
-	// Scan the table to find all books that are currently active
-	filt := expression.Name("Active").Equal(expression.Value(true))
-	expr, err := expression.NewBuilder().WithFilter(filt).Build()
-	if err != nil {
-		log.Fatalf("Got error building expression: %s", err)
-	}
-
-	booksTable := tableName("books")
-	scanInput := &dynamodb.ScanInput{
-		TableName:                 aws.String(booksTable),
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		FilterExpression:          expr.Filter(),
-	}
-
-	result, err := svc.Scan(scanInput)
-	if err != nil {
-		log.Fatalf("Query API call failed: %s", err)
-	}
-
-	// Update each active book to be inactive
-	for _, item := range result.Items {
-		var book Book
-		err = dynamodbattribute.UnmarshalMap(item, &book)
-		if err != nil {
-			log.Fatalf("Failed to unmarshal Book, %v", err)
-		}
-
-		booksTable := tableName("books")
-		updateInput := &dynamodb.UpdateItemInput{
-			TableName: aws.String(booksTable),
-			Key: map[string]*dynamodb.AttributeValue{
-				"BookID": {
-					S: aws.String(book.BookID),
-				},
-			},
-			UpdateExpression: aws.String("set Active = :val"),
-			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-				":val": {
-					BOOL: aws.Bool(false),
-				},
-			},
-		}
-
-		_, err = svc.UpdateItem(updateInput)
-		if err != nil {
-			log.Fatalf("Got error calling UpdateItem: %s", err)
-		}
-	}
+	deactivateBooks(svc)
 
 	// Step 2: Add the new book as active
-	// Use the current timestamp as BookID
-	bookID := strconv.FormatInt(time.Now().UnixNano(), 10)
+	bookID := NextBookID()
 
 	newBook := Book{
-		BookID: bookID,
-		Title:  title,
-		Active: true,
+		BookID:    bookID,
+		Title:     title,
+		Active:    true,
+		StartedAt: time.Now().UTC(),
 	}
 
 	newBookItem, err := dynamodbattribute.MarshalMap(newBook)
@@ -313,7 +453,7 @@ func AddBook(title string) {
 		log.Fatalf("Got error marshalling new book item: %s", err)
 	}
 
-	booksTable = tableName("books")
+	booksTable := tableName("books")
 	putInput := &dynamodb.PutItemInput{
 		TableName: aws.String(booksTable),
 		Item:      newBookItem,
@@ -386,6 +526,41 @@ func UpdateBookDate(bookID, date string) {
 	log.Println("Successfully updated book's author")
 }
 
+// UpdateBookField sets a single attribute of a book, e.g. "Genre" or "Editions".
+func UpdateBookField(bookID, field string, value interface{}) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+	booksTable := tableName("books")
+
+	av, err := dynamodbattribute.Marshal(value)
+	if err != nil {
+		log.Fatalf("Failed to marshal book %s: %s", field, err)
+	}
+
+	updateInput := &dynamodb.UpdateItemInput{
+		TableName: aws.String(booksTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"BookID": {
+				S: aws.String(bookID),
+			},
+		},
+		UpdateExpression: aws.String("set #f = :v"),
+		ExpressionAttributeNames: map[string]*string{
+			"#f": aws.String(field),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":v": av,
+		},
+	}
+
+	_, err = svc.UpdateItem(updateInput)
+	if err != nil {
+		log.Fatalf("Got error calling UpdateItem for %s update: %s", field, err)
+	}
+
+	log.Printf("Successfully updated book's %s", field)
+}
+
 func GetCurrentBook() Book {
 	sess := AWSsession()
 	svc := dynamodb.New(sess)
@@ -440,28 +615,67 @@ func SetProgress(progress ReadingProgress) {
 
 	readingProgressTable := tableName("reading_progress")
 	input := &dynamodb.PutItemInput{
-		TableName: aws.String(readingProgressTable),
-		Item:      av,
+		TableName:    aws.String(readingProgressTable),
+		Item:         av,
+		ReturnValues: aws.String("ALL_OLD"),
 	}
 
-	_, err = svc.PutItem(input)
+	result, err := svc.PutItem(input)
 	if err != nil {
 		log.Fatalf("Failed to put reading progress item into DynamoDB: %s", err)
 	}
 
 	log.Printf("Updated reading progress for user '%s' on book '%s'.\n", progress.UserName, progress.BookID)
-}
 
-func GroupProgress() string {
-	sess := AWSsession()
-	svc := dynamodb.New(sess)
+	var previous ReadingProgress
+	if err := dynamodbattribute.UnmarshalMap(result.Attributes, &previous); err != nil {
+		log.Fatalf("Failed to unmarshal previous reading progress: %s", err)
+	}
+	if progress.Progress > 0 && progress.moved(previous) {
+		addProgressEvent(ProgressEvent{UserName: progress.UserName, Timestamp: time.Now().UTC(), BookID: progress.BookID, Progress: progress.Progress})
+	}
+}
 
+func GroupProgress(lang string) string {
 	activeBook := GetCurrentBook()
 	if activeBook.BookID == "" {
-		return "No active book found."
+		return i18n.T(lang, "No active book found.")
 	}
 
-	keyCond := expression.Key("BookID").Equal(expression.Value(activeBook.BookID))
+	progresses := BookProgress(activeBook.BookID)
+	result := FormatGroupProgress(progresses, lang)
+	milestone, ok := activeBook.NextMilestone(time.Now().In(ClubLocation()))
+	if !ok || len(progresses) == 0 {
+		return result
+	}
+	result = i18n.T(lang, "Next milestone: %s (%d%%) by %s", milestone.Title, milestone.Percent, milestone.Deadline) + "\n\n" + result
+	var behind []string
+	for _, progress := range progresses {
+		if progress.Progress < milestone.Percent {
+			behind = append(behind, GetUserDetails(progress.UserName).FullName)
+		}
+	}
+	if len(behind) > 0 {
+		result += "\n" + i18n.T(lang, "Not there yet: ") + strings.Join(behind, ", ") + "\n"
+	}
+	return result
+}
+
+// ProgressList returns the progress of every member in every book.
+func ProgressList() []ReadingProgress {
+	progress, err := FetchProgress()
+	if err != nil {
+		log.Fatalf("Failed to list reading progress: %s", err)
+	}
+	return progress
+}
+
+// BookProgress returns every member's progress on a book, most advanced first.
+func BookProgress(bookID string) []ReadingProgress {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	keyCond := expression.Key("BookID").Equal(expression.Value(bookID))
 	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
 	if err != nil {
 		log.Fatalf("Failed to build expression: %s", err)
@@ -490,14 +704,22 @@ func GroupProgress() string {
 		return progresses[i].Progress > progresses[j].Progress
 	})
 
+	return progresses
+}
+
+func FormatGroupProgress(progresses []ReadingProgress, lang string) string {
 	var groupProgress string
 	for _, progress := range progresses {
 		user := GetUserDetails(progress.UserName)
-		groupProgress += fmt.Sprintf("%s: %d%%\n", user.FullName, progress.Progress)
+		groupProgress += fmt.Sprintf("%s: %d%%", user.FullName, progress.Progress)
+		if position := progress.Position(lang); position != "" {
+			groupProgress += " (" + position + ")"
+		}
+		groupProgress += "\n"
 	}
 
 	if groupProgress == "" {
-		return "No users have set their progress yet."
+		return i18n.T(lang, "No users have set their progress yet.")
 	}
 
 	return groupProgress
@@ -594,57 +816,6 @@ func AddUser(userName string, name string) {
 	fmt.Printf("Successfully added user: %s\n", userName)
 }
 
-func SetUserFullName(name string) {
-	sess := AWSsession()
-	svc := dynamodb.New(sess)
-	usersTable := tableName("users")
-	scanInput := &dynamodb.ScanInput{
-		TableName:        aws.String(usersTable),
-		FilterExpression: aws.String("attribute_not_exists(FullName) or attribute_type(FullName, :nullType)"),
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":nullType": {S: aws.String("NULL")},
-		},
-	}
-
-	// Perform the scan
-	scanResult, err := svc.Scan(scanInput)
-	fmt.Println("scanResult", scanResult)
-	if err != nil {
-		log.Fatalf("Failed to scan DynamoDB table: %s", err)
-	}
-
-	// Update each item found with the new name
-	for _, item := range scanResult.Items {
-		user := User{}
-		err := dynamodbattribute.UnmarshalMap(item, &user)
-		if err != nil {
-			log.Fatalf("Failed to unmarshal DynamoDB item: %s", err)
-		}
-
-		// Set the new name
-		user.FullName = name
-
-		// Marshal the updated user back into a DynamoDB attribute map
-		updatedAttributes, err := dynamodbattribute.MarshalMap(user)
-		if err != nil {
-			log.Fatalf("Failed to marshal updated user: %s", err)
-		}
-
-		// Create the input for the update
-		updateInput := &dynamodb.PutItemInput{
-			TableName: aws.String(usersTable),
-			Item:      updatedAttributes,
-		}
-
-		_, err = svc.PutItem(updateInput)
-		if err != nil {
-			log.Fatalf("Failed to update item in DynamoDB table: %s", err)
-		}
-
-		fmt.Printf("Successfully updated user: %s\n", user.UserName)
-	}
-}
-
 func RemoveUser(userName string) {
 	sess := AWSsession()
 	svc := dynamodb.New(sess)
@@ -667,28 +838,37 @@ func RemoveUser(userName string) {
 	}
 }
 
-func BookList() []Book {
+// RestoreUser puts back the record of a removed member. Their reading progress
+// is kept by RemoveUser, so it is theirs again as well.
+func RestoreUser(user User) {
 	sess := AWSsession()
 	svc := dynamodb.New(sess)
 
-	booksTable := tableName("books")
-	input := &dynamodb.ScanInput{
-		TableName: aws.String(booksTable),
+	user.Status = ""
+	user.Draft = ""
+	item, err := dynamodbattribute.MarshalMap(user)
+	if err != nil {
+		log.Fatalf("Failed to marshal User: %s", err)
 	}
 
-	result, err := svc.Scan(input)
+	usersTable := tableName("users")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(usersTable),
+		Item:      item,
+	})
 	if err != nil {
-		log.Fatalf("Query API call failed: %s", err)
+		log.Fatalf("Failed to put User item into DynamoDB table: %s", err)
 	}
 
-	var bookList []Book
+	log.Printf("User '%s' restored successfully.", user.UserName)
+}
 
-	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &bookList)
+func BookList() []Book {
+	books, err := FetchBooks()
 	if err != nil {
-		log.Fatalf("Failed to unmarshal Query result items, %v", err)
+		log.Fatalf("Failed to list books: %s", err)
 	}
-
-	return bookList
+	return books
 }
 
 func UserStatus(userName string) string {
@@ -713,6 +893,86 @@ func UserStatus(userName string) string {
 }
 
 func SetUserStatus(userName string, status string) {
+	setUserAttribute(userName, "Status", status)
+}
+
+func UserDraft(userName string) string {
+	return GetUserDetails(userName).Draft
+}
+
+func SetUserDraft(userName string, draft string) {
+	setUserAttribute(userName, "Draft", draft)
+}
+
+// SetUserChatID remembers the member's private chat so the bot can message them first.
+func SetUserChatID(userName string, chatID int64) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	usersTable := tableName("users")
+	_, err := svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(usersTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"UserName": {
+				S: aws.String(userName),
+			},
+		},
+		UpdateExpression: aws.String("SET ChatID = :c"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":c": {
+				N: aws.String(strconv.FormatInt(chatID, 10)),
+			},
+		},
+	})
+	if err != nil {
+		fmt.Printf("Failed to update item: %v\n", err)
+	}
+}
+
+func SetUserDisplayName(userName, name string) {
+	setUserAttribute(userName, "FullName", name)
+}
+
+func SetUserLanguage(userName, language string) {
+	setUserAttribute(userName, "Language", language)
+}
+
+func SetUserTelegramLanguage(userName, language string) {
+	setUserAttribute(userName, "TelegramLanguage", language)
+}
+
+// UpdateUserField sets a single non-string attribute of a user, e.g. a reminder opt-out.
+func UpdateUserField(userName, field string, value interface{}) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	av, err := dynamodbattribute.Marshal(value)
+	if err != nil {
+		log.Fatalf("Failed to marshal user %s: %s", field, err)
+	}
+
+	usersTable := tableName("users")
+	_, err = svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(usersTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"UserName": {
+				S: aws.String(userName),
+			},
+		},
+		UpdateExpression: aws.String("set #f = :v"),
+		ExpressionAttributeNames: map[string]*string{
+			"#f": aws.String(field),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":v": av,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Got error calling UpdateItem for user %s update: %s", field, err)
+	}
+}
+
+func setUserAttribute(userName string, attribute string, value string) {
 	sess := AWSsession()
 	svc := dynamodb.New(sess)
 
@@ -725,11 +985,11 @@ func SetUserStatus(userName string, status string) {
 	updateExpression := "SET #st = :s"
 
 	expressionAttributeNames := map[string]*string{
-		"#st": aws.String("Status"),
+		"#st": aws.String(attribute),
 	}
 	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
 		":s": {
-			S: aws.String(status),
+			S: aws.String(value),
 		},
 	}
 	usersTable := tableName("users")
@@ -748,7 +1008,7 @@ func SetUserStatus(userName string, status string) {
 		return
 	}
 
-	fmt.Println("User status updated successfully")
+	fmt.Printf("User %s updated successfully\n", strings.ToLower(attribute))
 }
 
 func UserProgress(userName string) *ReadingProgress {
@@ -793,7 +1053,3 @@ func UserProgress(userName string) *ReadingProgress {
 	// Assuming there is only one record per user per book
 	return &progresses[0]
 }
-
-// func main() {
-// 	SetUserFullName("111")
-// }
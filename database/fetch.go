@@ -0,0 +1,176 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// The Fetch functions read whole tables like UserList or BookList, but return
+// errors instead of stopping the bot. The HTTP handlers use them, where a failed
+// read should end the request, not the process.
+
+// scanTable reads every item of table into out, a pointer to a slice.
+func scanTable(table string, out interface{}) error {
+	environment := os.Getenv("ENV")
+	if environment == "" {
+		return errors.New("there is no environment")
+	}
+	svc := dynamodb.New(AWSsession())
+
+	var items []map[string]*dynamodb.AttributeValue
+	err := svc.ScanPages(&dynamodb.ScanInput{
+		TableName: aws.String(TableName(table, environment)),
+	}, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+		items = append(items, page.Items...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", table, err)
+	}
+	if err := dynamodbattribute.UnmarshalListOfMaps(items, out); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", table, err)
+	}
+	return nil
+}
+
+// FetchClubSettings returns the club's settings, empty if none were saved yet.
+func FetchClubSettings() (Settings, error) {
+	environment := os.Getenv("ENV")
+	if environment == "" {
+		return Settings{}, errors.New("there is no environment")
+	}
+	svc := dynamodb.New(AWSsession())
+
+	result, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(TableName("settings", environment)),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ClubID": {
+				S: aws.String(clubSettingsID),
+			},
+		},
+	})
+	if err != nil {
+		return Settings{}, fmt.Errorf("get club settings: %w", err)
+	}
+	settings := Settings{ClubID: clubSettingsID}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &settings); err != nil {
+		return Settings{}, fmt.Errorf("unmarshal club settings: %w", err)
+	}
+	return settings, nil
+}
+
+// FetchUsers returns every member of the club.
+func FetchUsers() ([]User, error) {
+	var users []User
+	err := scanTable("users", &users)
+	return users, err
+}
+
+// FetchBooks returns every book in the club's reading order.
+func FetchBooks() ([]Book, error) {
+	var books []Book
+	if err := scanTable("books", &books); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(books, func(i, j int) bool {
+		return books[i].Started().Before(books[j].Started())
+	})
+	return books, nil
+}
+
+// FetchMeetings returns every saved meeting ordered by date. Meetings without a
+// timezone get the club's.
+func FetchMeetings() ([]Meeting, error) {
+	settings, err := FetchClubSettings()
+	if err != nil {
+		return nil, err
+	}
+	return fetchMeetings(settings.clubTimezone())
+}
+
+func fetchMeetings(clubTimezone string) ([]Meeting, error) {
+	var meetings []Meeting
+	if err := scanTable("meetings", &meetings); err != nil {
+		return nil, err
+	}
+	for i := range meetings {
+		if meetings[i].Timezone == "" {
+			meetings[i].Timezone = clubTimezone
+		}
+	}
+	sortMeetings(meetings)
+	return meetings, nil
+}
+
+// FetchBookMeetings returns the meetings of every book in books, book by book,
+// like BookMeetings does for one book but reading the meetings table only once.
+func FetchBookMeetings(books []Book) ([]Meeting, error) {
+	settings, err := FetchClubSettings()
+	if err != nil {
+		return nil, err
+	}
+	saved, err := fetchMeetings(settings.clubTimezone())
+	if err != nil {
+		return nil, err
+	}
+	byBook := map[string][]Meeting{}
+	for _, meeting := range saved {
+		byBook[meeting.BookID] = append(byBook[meeting.BookID], meeting)
+	}
+	var meetings []Meeting
+	for _, book := range books {
+		meetings = append(meetings, bookMeetings(book, byBook[book.BookID], settings.clubTimezone())...)
+	}
+	return meetings, nil
+}
+
+// FetchProgress returns the current progress of every member in every book.
+func FetchProgress() ([]ReadingProgress, error) {
+	var progress []ReadingProgress
+	err := scanTable("reading_progress", &progress)
+	return progress, err
+}
+
+// FetchRSVPs returns every answer to every meeting.
+func FetchRSVPs() ([]RSVP, error) {
+	var rsvps []RSVP
+	err := scanTable("rsvps", &rsvps)
+	return rsvps, err
+}
+
+// FetchProgressHistory returns every recorded progress update, oldest first.
+func FetchProgressHistory() ([]ProgressEvent, error) {
+	var events []ProgressEvent
+	if err := scanTable("progress_history", &events); err != nil {
+		return nil, err
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+// FetchQuotes returns every saved quote of every book, in the order they were added.
+func FetchQuotes() ([]Quote, error) {
+	var quotes []Quote
+	if err := scanTable("quotes", &quotes); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].QuoteID < quotes[j].QuoteID
+	})
+	return quotes, nil
+}
+
+// FetchRatings returns every member's rating and review of every book.
+func FetchRatings() ([]Rating, error) {
+	var ratings []Rating
+	err := scanTable("ratings", &ratings)
+	return ratings, err
+}
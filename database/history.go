@@ -0,0 +1,47 @@
+package database
+
+import (
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// ProgressEvent is one progress update. Unlike ReadingProgress, which only keeps
+// the latest position, every update is kept so streaks and badges can be computed.
+type ProgressEvent struct {
+	UserName  string    `dynamodbav:"UserName"`
+	Timestamp time.Time `dynamodbav:"Timestamp"`
+	BookID    string    `dynamodbav:"BookID"`
+	Progress  int       `dynamodbav:"Progress"`
+}
+
+func addProgressEvent(event ProgressEvent) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	item, err := dynamodbattribute.MarshalMap(event)
+	if err != nil {
+		log.Fatalf("Failed to marshal progress event: %s", err)
+	}
+
+	historyTable := tableName("progress_history")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(historyTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Failed to put progress event into DynamoDB: %s", err)
+	}
+}
+
+// ProgressHistory returns every recorded progress update, oldest first.
+func ProgressHistory() []ProgressEvent {
+	events, err := FetchProgressHistory()
+	if err != nil {
+		log.Fatalf("Failed to read progress history: %s", err)
+	}
+	return events
+}
@@ -0,0 +1,286 @@
+package database
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+type Meeting struct {
+	MeetingID string `dynamodbav:"MeetingID"`
+	BookID    string `dynamodbav:"BookID"`
+	Title     string `dynamodbav:"Title"`
+	Date      string `dynamodbav:"Date"` // dd.mm.yyyy
+	Time      string `dynamodbav:"Time"` // hh:mm, empty for an all-day meeting
+	Timezone  string `dynamodbav:"Timezone"`
+	Location  string `dynamodbav:"Location"`
+	Link      string `dynamodbav:"Link"`
+	Agenda    string `dynamodbav:"Agenda"`
+}
+
+type RSVPStatus string
+
+const (
+	Going    RSVPStatus = "going"
+	Maybe    RSVPStatus = "maybe"
+	NotGoing RSVPStatus = "not_going"
+)
+
+type RSVP struct {
+	MeetingID string     `dynamodbav:"MeetingID"`
+	UserName  string     `dynamodbav:"UserName"`
+	Status    RSVPStatus `dynamodbav:"Status"`
+}
+
+// TimeLocation returns the meeting's timezone. Meetings read through MeetingList
+// and BookMeetings always have one; UTC is only a last resort.
+func (m Meeting) TimeLocation() *time.Location {
+	if m.Timezone != "" {
+		if loc, err := time.LoadLocation(m.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// Start returns when the meeting begins. All-day meetings start at midnight.
+func (m Meeting) Start() (time.Time, error) {
+	if m.Time == "" {
+		return time.ParseInLocation("02.01.2006", m.Date, m.TimeLocation())
+	}
+	return time.ParseInLocation("02.01.2006 15:04", m.Date+" "+m.Time, m.TimeLocation())
+}
+
+// FinalMeetingID is the ID of the meeting that mirrors Book.MeetingDate.
+func FinalMeetingID(bookID string) string {
+	return bookID + "-final"
+}
+
+// AddMeeting saves a new meeting and returns its ID.
+func AddMeeting(meeting Meeting) string {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	meeting.MeetingID = strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	item, err := dynamodbattribute.MarshalMap(meeting)
+	if err != nil {
+		log.Fatalf("Got error marshalling new meeting item: %s", err)
+	}
+
+	meetingsTable := tableName("meetings")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(meetingsTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Got error calling PutItem: %s", err)
+	}
+
+	log.Printf("Successfully added meeting '%s' for book '%s'", meeting.Title, meeting.BookID)
+	return meeting.MeetingID
+}
+
+// UpdateMeetingField sets a single attribute of a meeting, creating the meeting if needed.
+func UpdateMeetingField(meetingID, field string, value interface{}) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	av, err := dynamodbattribute.Marshal(value)
+	if err != nil {
+		log.Fatalf("Failed to marshal meeting %s: %s", field, err)
+	}
+
+	meetingsTable := tableName("meetings")
+	_, err = svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(meetingsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"MeetingID": {
+				S: aws.String(meetingID),
+			},
+		},
+		UpdateExpression: aws.String("set #f = :v"),
+		ExpressionAttributeNames: map[string]*string{
+			"#f": aws.String(field),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":v": av,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Got error calling UpdateItem for meeting %s update: %s", field, err)
+	}
+}
+
+// RemoveMeeting deletes a meeting, e.g. when the book it belongs to is removed.
+func RemoveMeeting(meetingID string) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	meetingsTable := tableName("meetings")
+	_, err := svc.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(meetingsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"MeetingID": {
+				S: aws.String(meetingID),
+			},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to delete meeting '%s': %s", meetingID, err)
+	}
+	log.Printf("Meeting '%s' removed successfully.", meetingID)
+}
+
+// SetFinalMeetingDate keeps the book's final meeting in sync with Book.MeetingDate.
+func SetFinalMeetingDate(bookID, date string) {
+	meetingID := FinalMeetingID(bookID)
+	UpdateMeetingField(meetingID, "BookID", bookID)
+	UpdateMeetingField(meetingID, "Title", "Final meeting")
+	UpdateMeetingField(meetingID, "Date", date)
+	if GetMeeting(meetingID).Timezone == "" {
+		UpdateMeetingField(meetingID, "Timezone", ClubTimezone())
+	}
+}
+
+func GetMeeting(meetingID string) Meeting {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	meetingsTable := tableName("meetings")
+	result, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(meetingsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"MeetingID": {
+				S: aws.String(meetingID),
+			},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to get meeting '%s': %s", meetingID, err)
+	}
+
+	var meeting Meeting
+	err = dynamodbattribute.UnmarshalMap(result.Item, &meeting)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal meeting: %s", err)
+	}
+
+	return meeting
+}
+
+func MeetingList() []Meeting {
+	meetings, err := FetchMeetings()
+	if err != nil {
+		log.Fatalf("Failed to list meetings: %s", err)
+	}
+	return meetings
+}
+
+// BookMeetings returns the meetings of a book ordered by date. Books created before
+// meetings existed only have Book.MeetingDate, which is returned as the final meeting.
+func BookMeetings(book Book) []Meeting {
+	var saved []Meeting
+	for _, meeting := range MeetingList() {
+		if meeting.BookID == book.BookID {
+			saved = append(saved, meeting)
+		}
+	}
+	return bookMeetings(book, saved, ClubTimezone())
+}
+
+// bookMeetings adds the final meeting of Book.MeetingDate to the saved meetings
+// of book if it is missing.
+func bookMeetings(book Book, saved []Meeting, clubTimezone string) []Meeting {
+	meetings := saved
+	for _, meeting := range saved {
+		if meeting.MeetingID == FinalMeetingID(book.BookID) {
+			return meetings
+		}
+	}
+	if book.MeetingDate != "" {
+		meetings = append(meetings, Meeting{
+			MeetingID: FinalMeetingID(book.BookID),
+			BookID:    book.BookID,
+			Title:     "Final meeting",
+			Date:      book.MeetingDate,
+			Timezone:  clubTimezone,
+		})
+		sortMeetings(meetings)
+	}
+	return meetings
+}
+
+func sortMeetings(meetings []Meeting) {
+	sort.SliceStable(meetings, func(i, j int) bool {
+		a, _ := meetings[i].Start()
+		b, _ := meetings[j].Start()
+		return a.Before(b)
+	})
+}
+
+func SetRSVP(meetingID, userName string, status RSVPStatus) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	item, err := dynamodbattribute.MarshalMap(RSVP{MeetingID: meetingID, UserName: userName, Status: status})
+	if err != nil {
+		log.Fatalf("Failed to marshal RSVP: %s", err)
+	}
+
+	rsvpsTable := tableName("rsvps")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(rsvpsTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Failed to put RSVP item into DynamoDB: %s", err)
+	}
+
+	log.Printf("User '%s' answered '%s' for meeting '%s'.", userName, status, meetingID)
+}
+
+// RSVPList returns every answer to every meeting.
+func RSVPList() []RSVP {
+	rsvps, err := FetchRSVPs()
+	if err != nil {
+		log.Fatalf("Failed to list RSVPs: %s", err)
+	}
+	return rsvps
+}
+
+func MeetingRSVPs(meetingID string) []RSVP {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	keyCond := expression.Key("MeetingID").Equal(expression.Value(meetingID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		log.Fatalf("Failed to build expression: %s", err)
+	}
+
+	rsvpsTable := tableName("rsvps")
+	result, err := svc.Query(&dynamodb.QueryInput{
+		TableName:                 aws.String(rsvpsTable),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to query RSVPs: %s", err)
+	}
+
+	var rsvps []RSVP
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &rsvps)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal RSVPs: %s", err)
+	}
+
+	return rsvps
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+type NoteKind string
+
+const (
+	Question    NoteKind = "question"
+	ReadingNote NoteKind = "note"
+)
+
+// Note is a discussion question or a note about the book. Percent is the position
+// in the book the note refers to; 0 means it doesn't give anything away.
+type Note struct {
+	BookID   string   `dynamodbav:"BookID"`
+	NoteID   string   `dynamodbav:"NoteID"`
+	UserName string   `dynamodbav:"UserName"`
+	Kind     NoteKind `dynamodbav:"Kind"`
+	Text     string   `dynamodbav:"Text"`
+	Percent  int      `dynamodbav:"Percent"`
+	Label    string   `dynamodbav:"Label"` // the position as the member entered it, e.g. "page 120"
+}
+
+func AddNote(note Note) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	note.NoteID = strconv.FormatInt(time.Now().UnixNano(), 10)
+	item, err := dynamodbattribute.MarshalMap(note)
+	if err != nil {
+		log.Fatalf("Failed to marshal note: %s", err)
+	}
+
+	notesTable := tableName("notes")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(notesTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Failed to put note item into DynamoDB: %s", err)
+	}
+
+	log.Printf("User '%s' added a %s for book '%s'.", note.UserName, note.Kind, note.BookID)
+}
+
+// BookNotes returns the questions and notes of a book ordered by position.
+func BookNotes(bookID string) []Note {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	keyCond := expression.Key("BookID").Equal(expression.Value(bookID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		log.Fatalf("Failed to build expression: %s", err)
+	}
+
+	notesTable := tableName("notes")
+	result, err := svc.Query(&dynamodb.QueryInput{
+		TableName:                 aws.String(notesTable),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to query notes: %s", err)
+	}
+
+	var notes []Note
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &notes)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal notes: %s", err)
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Percent < notes[j].Percent
+	})
+	return notes
+}
@@ -0,0 +1,27 @@
+package database
+
+import "testing"
+
+func TestMoved(t *testing.T) {
+	page := ReadingProgress{Type: RegularBook, PageNumber: 100, TotalPages: 300, Progress: 33}
+	tests := []struct {
+		name     string
+		progress ReadingProgress
+		previous ReadingProgress
+		want     bool
+	}{
+		{"first update", page, ReadingProgress{}, true},
+		{"next page", page, ReadingProgress{Type: RegularBook, PageNumber: 80, TotalPages: 300, Progress: 27}, true},
+		{"same page again", page, page, false},
+		{"corrected total pages", page.WithTotalPages(200), page, false},
+		{"changed format", page.ConvertTo(AudioBook, 600), page, false},
+		{"kindle location", ReadingProgress{Type: KindleBook, Location: 50}, ReadingProgress{Type: KindleBook, Location: 40}, true},
+		{"listened further", ReadingProgress{Type: AudioBook, ListenedMinutes: 90}, ReadingProgress{Type: AudioBook, ListenedMinutes: 60}, true},
+		{"e-book percent", ReadingProgress{Type: EBook, Progress: 40}, ReadingProgress{Type: EBook, Progress: 40}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.progress.moved(tt.previous); got != tt.want {
+			t.Errorf("%s: moved = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
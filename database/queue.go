@@ -0,0 +1,90 @@
+package database
+
+import (
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// QueuedBooks returns the upcoming books in reading order.
+func QueuedBooks() []Book {
+	var queue []Book
+	for _, book := range BookList() {
+		if book.Queued {
+			queue = append(queue, book)
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].QueuePosition < queue[j].QueuePosition
+	})
+	return queue
+}
+
+// QueueBook adds a book to the end of the queue without changing the current book.
+func QueueBook(title string) string {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	position := 1
+	if queue := QueuedBooks(); len(queue) > 0 {
+		position = queue[len(queue)-1].QueuePosition + 1
+	}
+
+	book := Book{
+		BookID:        NextBookID(),
+		Title:         title,
+		Queued:        true,
+		QueuePosition: position,
+	}
+
+	item, err := dynamodbattribute.MarshalMap(book)
+	if err != nil {
+		log.Fatalf("Got error marshalling queued book item: %s", err)
+	}
+
+	booksTable := tableName("books")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(booksTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Got error calling PutItem: %s", err)
+	}
+
+	log.Printf("Successfully queued book '%s' at position %d", title, position)
+	return book.BookID
+}
+
+// MoveQueuedBook puts a queued book at the given 1-based position and renumbers the queue.
+func MoveQueuedBook(bookID string, position int) {
+	queue := QueuedBooks()
+	var moved *Book
+	var rest []Book
+	for i := range queue {
+		if queue[i].BookID == bookID {
+			moved = &queue[i]
+			continue
+		}
+		rest = append(rest, queue[i])
+	}
+	if moved == nil {
+		return
+	}
+	position = max(1, min(position, len(queue)))
+	reordered := append([]Book{}, rest[:position-1]...)
+	reordered = append(reordered, *moved)
+	reordered = append(reordered, rest[position-1:]...)
+	for i, book := range reordered {
+		if book.QueuePosition != i+1 {
+			UpdateBookField(book.BookID, "QueuePosition", i+1)
+		}
+	}
+}
+
+// UnqueueBook removes a book that hasn't been read from the queue.
+func UnqueueBook(bookID string) {
+	RemoveBook(bookID)
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+type Quote struct {
+	BookID    string `dynamodbav:"BookID"`
+	QuoteID   string `dynamodbav:"QuoteID"`
+	UserName  string `dynamodbav:"UserName"`
+	Text      string `dynamodbav:"Text"`
+	Reference string `dynamodbav:"Reference"` // page or chapter, e.g. "p. 120" or "Chapter 3"
+}
+
+func AddQuote(quote Quote) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	quote.QuoteID = strconv.FormatInt(time.Now().UnixNano(), 10)
+	item, err := dynamodbattribute.MarshalMap(quote)
+	if err != nil {
+		log.Fatalf("Failed to marshal quote: %s", err)
+	}
+
+	quotesTable := tableName("quotes")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(quotesTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Failed to put quote item into DynamoDB: %s", err)
+	}
+
+	log.Printf("User '%s' added a quote for book '%s'.", quote.UserName, quote.BookID)
+}
+
+// BookQuotes returns the quotes of a book in the order they were added.
+func BookQuotes(bookID string) []Quote {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	keyCond := expression.Key("BookID").Equal(expression.Value(bookID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		log.Fatalf("Failed to build expression: %s", err)
+	}
+
+	quotesTable := tableName("quotes")
+	result, err := svc.Query(&dynamodb.QueryInput{
+		TableName:                 aws.String(quotesTable),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to query quotes: %s", err)
+	}
+
+	var quotes []Quote
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &quotes)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal quotes: %s", err)
+	}
+
+	return quotes
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+type Rating struct {
+	BookID   string `dynamodbav:"BookID"`
+	UserName string `dynamodbav:"UserName"`
+	Rating   int    `dynamodbav:"Rating"`
+	Review   string `dynamodbav:"Review"`
+}
+
+func SetRating(rating Rating) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	if rating.UserName == "" || rating.BookID == "" {
+		log.Println("Invalid rating data: missing key attributes.")
+		return
+	}
+
+	item, err := dynamodbattribute.MarshalMap(rating)
+	if err != nil {
+		log.Fatalf("Failed to marshal rating: %s", err)
+	}
+
+	ratingsTable := tableName("ratings")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(ratingsTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Failed to put rating item into DynamoDB: %s", err)
+	}
+
+	log.Printf("User '%s' rated book '%s' with %d.", rating.UserName, rating.BookID, rating.Rating)
+}
+
+func BookRatings(bookID string) []Rating {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	keyCond := expression.Key("BookID").Equal(expression.Value(bookID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		log.Fatalf("Failed to build expression: %s", err)
+	}
+
+	ratingsTable := tableName("ratings")
+	result, err := svc.Query(&dynamodb.QueryInput{
+		TableName:                 aws.String(ratingsTable),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to query ratings: %s", err)
+	}
+
+	var ratings []Rating
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &ratings)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal ratings: %s", err)
+	}
+
+	return ratings
+}
+
+func HasRated(bookID, userName string) bool {
+	for _, rating := range BookRatings(bookID) {
+		if rating.UserName == userName {
+			return true
+		}
+	}
+	return false
+}
+
+// AverageRating returns the mean rating and the number of ratings.
+func AverageRating(ratings []Rating) (float64, int) {
+	if len(ratings) == 0 {
+		return 0, 0
+	}
+	sum := 0
+	for _, rating := range ratings {
+		sum += rating.Rating
+	}
+	return float64(sum) / float64(len(ratings)), len(ratings)
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"log"
+	"os"
+	"telegram-bot/i18n"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// clubSettingsID is the key of the single settings item of the club.
+const clubSettingsID = "club"
+
+type Settings struct {
+	ClubID   string `dynamodbav:"ClubID"`
+	Timezone string `dynamodbav:"Timezone"`
+	Language string `dynamodbav:"Language"`
+}
+
+func ClubSettings() Settings {
+	settings, err := FetchClubSettings()
+	if err != nil {
+		log.Fatalf("Failed to read club settings: %s", err)
+	}
+	return settings
+}
+
+// SetClubSetting sets a single attribute of the club settings, e.g. "Timezone".
+func SetClubSetting(field string, value interface{}) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	av, err := dynamodbattribute.Marshal(value)
+	if err != nil {
+		log.Fatalf("Failed to marshal club setting %s: %s", field, err)
+	}
+
+	settingsTable := tableName("settings")
+	_, err = svc.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(settingsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ClubID": {
+				S: aws.String(clubSettingsID),
+			},
+		},
+		UpdateExpression: aws.String("set #f = :v"),
+		ExpressionAttributeNames: map[string]*string{
+			"#f": aws.String(field),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":v": av,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Got error calling UpdateItem for club setting %s: %s", field, err)
+	}
+
+	log.Printf("Successfully updated club setting %s", field)
+}
+
+// ClubTimezone returns the club's timezone name. Until an admin sets one,
+// the CLUB_TIMEZONE environment variable or UTC is used.
+func ClubTimezone() string {
+	return ClubSettings().clubTimezone()
+}
+
+func (s Settings) clubTimezone() string {
+	if s.Timezone != "" {
+		return s.Timezone
+	}
+	if timezone := os.Getenv("CLUB_TIMEZONE"); timezone != "" {
+		return timezone
+	}
+	return "UTC"
+}
+
+// ClubLanguage returns the language of the club, used for members who haven't chosen one.
+func ClubLanguage() string {
+	if language := ClubSettings().Language; language != "" {
+		return language
+	}
+	return i18n.Default
+}
+
+// UserLanguage returns the language messages to the member are written in.
+func UserLanguage(userName string) string {
+	return GetUserDetails(userName).Lang()
+}
+
+// Lang returns the member's language: their own choice, else the club's, else
+// the language of their Telegram app.
+func (u User) Lang() string {
+	if u.Language != "" {
+		return u.Language
+	}
+	if language := ClubSettings().Language; language != "" {
+		return language
+	}
+	if u.TelegramLanguage != "" {
+		return u.TelegramLanguage
+	}
+	return i18n.Default
+}
+
+func ClubLocation() *time.Location {
+	return loadLocation(ClubTimezone())
+}
+
+// UserLocation returns the member's own timezone, or the club's if they haven't set one.
+func UserLocation(userName string) *time.Location {
+	if timezone := GetUserDetails(userName).Timezone; timezone != "" {
+		return loadLocation(timezone)
+	}
+	return ClubLocation()
+}
+
+func SetUserTimezone(userName, timezone string) {
+	setUserAttribute(userName, "Timezone", timezone)
+}
+
+func loadLocation(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Printf("Unknown timezone '%s', using UTC: %s", timezone, err)
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,183 @@
+package database
+
+import (
+	"log"
+	"strconv"
+	"telegram-bot/i18n"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+type Shelf string
+
+const (
+	WantToRead Shelf = "want_to_read"
+	Reading    Shelf = "reading"
+	Read       Shelf = "read"
+)
+
+func (s Shelf) Label(lang string) string {
+	switch s {
+	case WantToRead:
+		return i18n.T(lang, "want to read")
+	case Reading:
+		return i18n.T(lang, "reading")
+	case Read:
+		return i18n.T(lang, "read")
+	}
+	return string(s)
+}
+
+// ShelfBook is a book a member reads on their own, outside the club. Progress uses
+// the same mechanics as the club book, with BookID set to ShelfID.
+type ShelfBook struct {
+	UserName  string          `dynamodbav:"UserName"`
+	ShelfID   string          `dynamodbav:"ShelfID"`
+	Title     string          `dynamodbav:"Title"`
+	Author    string          `dynamodbav:"Author"`
+	Shelf     Shelf           `dynamodbav:"Shelf"`
+	Progress  ReadingProgress `dynamodbav:"Progress"`
+	Nominated bool            `dynamodbav:"Nominated"`
+}
+
+func AddShelfBook(userName, title string) string {
+	book := ShelfBook{
+		UserName: userName,
+		ShelfID:  strconv.FormatInt(time.Now().UnixNano(), 10),
+		Title:    title,
+		Shelf:    WantToRead,
+	}
+	book.Progress = ReadingProgress{UserName: userName, BookID: book.ShelfID}
+	PutShelfBook(book)
+	return book.ShelfID
+}
+
+// PutShelfBook saves the whole shelf entry.
+func PutShelfBook(book ShelfBook) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	item, err := dynamodbattribute.MarshalMap(book)
+	if err != nil {
+		log.Fatalf("Failed to marshal shelf book: %s", err)
+	}
+
+	shelfTable := tableName("shelf")
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(shelfTable),
+		Item:      item,
+	})
+	if err != nil {
+		log.Fatalf("Failed to put shelf book into DynamoDB: %s", err)
+	}
+
+	log.Printf("Saved '%s' on the shelf of '%s'.", book.Title, book.UserName)
+}
+
+func GetShelfBook(userName, shelfID string) ShelfBook {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	shelfTable := tableName("shelf")
+	result, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(shelfTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"UserName": {S: aws.String(userName)},
+			"ShelfID":  {S: aws.String(shelfID)},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to get shelf book '%s': %s", shelfID, err)
+	}
+
+	var book ShelfBook
+	err = dynamodbattribute.UnmarshalMap(result.Item, &book)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal shelf book: %s", err)
+	}
+
+	return book
+}
+
+// UserShelf returns a member's shelf in the order the books were added.
+func UserShelf(userName string) []ShelfBook {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	keyCond := expression.Key("UserName").Equal(expression.Value(userName))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		log.Fatalf("Failed to build expression: %s", err)
+	}
+
+	shelfTable := tableName("shelf")
+	result, err := svc.Query(&dynamodb.QueryInput{
+		TableName:                 aws.String(shelfTable),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to query shelf: %s", err)
+	}
+
+	var books []ShelfBook
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &books)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal shelf: %s", err)
+	}
+
+	return books
+}
+
+func RemoveShelfBook(userName, shelfID string) {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	shelfTable := tableName("shelf")
+	_, err := svc.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(shelfTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"UserName": {S: aws.String(userName)},
+			"ShelfID":  {S: aws.String(shelfID)},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to delete shelf book '%s': %s", shelfID, err)
+	}
+}
+
+// Nominations returns the shelf books members have suggested to the club.
+func Nominations() []ShelfBook {
+	sess := AWSsession()
+	svc := dynamodb.New(sess)
+
+	filt := expression.Name("Nominated").Equal(expression.Value(true))
+	expr, err := expression.NewBuilder().WithFilter(filt).Build()
+	if err != nil {
+		log.Fatalf("Failed to build expression: %s", err)
+	}
+
+	shelfTable := tableName("shelf")
+	result, err := svc.Scan(&dynamodb.ScanInput{
+		TableName:                 aws.String(shelfTable),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to scan nominations: %s", err)
+	}
+
+	var books []ShelfBook
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &books)
+	if err != nil {
+		log.Fatalf("Failed to unmarshal nominations: %s", err)
+	}
+
+	return books
+}
@@ -0,0 +1,212 @@
+// Package dateparse understands the ways people type meeting dates in chat:
+// "25.12.2026", "2026-12-25", "25/12", "25 December", "next Friday", "in 3 weeks",
+// optionally followed by a time such as "19:00", "at 7pm" or "7:30 pm".
+package dateparse
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"telegram-bot/i18n"
+	"time"
+)
+
+// Result is an interpreted date. Date is midnight (or the given time) in now's location.
+type Result struct {
+	Date    time.Time
+	HasTime bool
+}
+
+var ErrNotUnderstood = errors.New("date not understood")
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January, "января": time.January, "январь": time.January, "янв": time.January,
+	"february": time.February, "feb": time.February, "февраля": time.February, "февраль": time.February, "фев": time.February,
+	"march": time.March, "mar": time.March, "марта": time.March, "март": time.March, "мар": time.March,
+	"april": time.April, "apr": time.April, "апреля": time.April, "апрель": time.April, "апр": time.April,
+	"may": time.May, "мая": time.May, "май": time.May,
+	"june": time.June, "jun": time.June, "июня": time.June, "июнь": time.June, "июн": time.June,
+	"july": time.July, "jul": time.July, "июля": time.July, "июль": time.July, "июл": time.July,
+	"august": time.August, "aug": time.August, "августа": time.August, "август": time.August, "авг": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September, "сентября": time.September, "сентябрь": time.September, "сен": time.September,
+	"october": time.October, "oct": time.October, "октября": time.October, "октябрь": time.October, "окт": time.October,
+	"november": time.November, "nov": time.November, "ноября": time.November, "ноябрь": time.November, "ноя": time.November,
+	"december": time.December, "dec": time.December, "декабря": time.December, "декабрь": time.December, "дек": time.December,
+}
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday, "понедельник": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "вторник": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "среда": time.Wednesday, "среду": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "четверг": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "пятница": time.Friday, "пятницу": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "суббота": time.Saturday, "субботу": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday, "воскресенье": time.Sunday,
+}
+
+var (
+	timePattern     = regexp.MustCompile(`(?:^|\s)(?:at\s+|в\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	numericPattern  = regexp.MustCompile(`^(\d{1,2})[./](\d{1,2})(?:[./](\d{2}|\d{4}))?$`)
+	isoPattern      = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	dayMonthPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?\s+(\pL+)\.?(?:\s+(\d{4}))?$`)
+	monthDayPattern = regexp.MustCompile(`^(\pL+)\.?\s+(\d{1,2})(?:st|nd|rd|th)?,?(?:\s+(\d{4}))?$`)
+	relativePattern = regexp.MustCompile(`^(?:in|через)\s+(a|an|one|\d+)?\s*(day|days|week|weeks|month|months|день|дня|дней|неделю|недели|недель|месяц|месяца|месяцев)$`)
+	weekdayPattern  = regexp.MustCompile(`^(?:(?:next|this|on|в|во|следующий|следующую|следующее)\s+)?(\pL+)$`)
+)
+
+// Parse interprets text relative to now. Dates without a year that have already
+// passed this year are taken to be next year.
+func Parse(text string, now time.Time) (Result, error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+	text = strings.TrimSuffix(text, ".")
+	if text == "" {
+		return Result{}, ErrNotUnderstood
+	}
+
+	datePart, hour, minute, hasTime := splitTime(text)
+	if datePart == "" {
+		return Result{}, ErrNotUnderstood
+	}
+
+	day, ok := parseDate(datePart, now)
+	if !ok {
+		return Result{}, ErrNotUnderstood
+	}
+	result := Result{Date: day}
+	if hasTime {
+		result.Date = time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+		result.HasTime = true
+	}
+	return result, nil
+}
+
+func splitTime(text string) (string, int, int, bool) {
+	match := timePattern.FindStringSubmatchIndex(text)
+	if match == nil {
+		return text, 0, 0, false
+	}
+	datePart := strings.TrimSpace(text[:match[0]])
+	groups := timePattern.FindStringSubmatch(text)
+	hasMinutes, hasMeridiem := groups[2] != "", groups[3] != ""
+	hasPrefix := strings.Contains(groups[0], "at ") || strings.Contains(groups[0], "в ")
+	// "25 december 2026" ends with a number that is a year, not an hour.
+	if datePart == "" || !hasMinutes && !hasMeridiem && !hasPrefix {
+		return text, 0, 0, false
+	}
+
+	hour, _ := strconv.Atoi(groups[1])
+	minute := 0
+	if hasMinutes {
+		minute, _ = strconv.Atoi(groups[2])
+	}
+	if hasMeridiem {
+		if hour < 1 || hour > 12 {
+			return "", 0, 0, false
+		}
+		if groups[3] == "pm" && hour < 12 {
+			hour += 12
+		}
+		if groups[3] == "am" && hour == 12 {
+			hour = 0
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return "", 0, 0, false
+	}
+	return datePart, hour, minute, true
+}
+
+func parseDate(text string, now time.Time) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch text {
+	case "today", "сегодня":
+		return today, true
+	case "tomorrow", "завтра":
+		return today.AddDate(0, 0, 1), true
+	case "day after tomorrow", "послезавтра":
+		return today.AddDate(0, 0, 2), true
+	}
+
+	if m := isoPattern.FindStringSubmatch(text); m != nil {
+		return makeDate(atoi(m[1]), atoi(m[2]), atoi(m[3]), now)
+	}
+	if m := numericPattern.FindStringSubmatch(text); m != nil {
+		return withYear(atoi(m[1]), time.Month(atoi(m[2])), m[3], today)
+	}
+	if m := dayMonthPattern.FindStringSubmatch(text); m != nil {
+		if month, ok := months[m[2]]; ok {
+			return withYear(atoi(m[1]), month, m[3], today)
+		}
+	}
+	if m := monthDayPattern.FindStringSubmatch(text); m != nil {
+		if month, ok := months[m[1]]; ok {
+			return withYear(atoi(m[2]), month, m[3], today)
+		}
+	}
+	if m := relativePattern.FindStringSubmatch(text); m != nil {
+		count := 1
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			count = n
+		}
+		switch {
+		case strings.HasPrefix(m[2], "day"), strings.HasPrefix(m[2], "д"):
+			return today.AddDate(0, 0, count), true
+		case strings.HasPrefix(m[2], "week"), strings.HasPrefix(m[2], "нед"):
+			return today.AddDate(0, 0, 7*count), true
+		default:
+			return today.AddDate(0, count, 0), true
+		}
+	}
+	if m := weekdayPattern.FindStringSubmatch(text); m != nil {
+		if weekday, ok := weekdays[m[1]]; ok {
+			// The next such day after today; "Friday" said on a Friday means a week later.
+			days := (int(weekday) - int(today.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, days), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// withYear builds a date from day and month, taking the year from text or,
+// if it is missing, the next occurrence of that day from today.
+func withYear(day int, month time.Month, year string, today time.Time) (time.Time, bool) {
+	if year != "" {
+		y := atoi(year)
+		if y < 100 {
+			y += 2000
+		}
+		return makeDate(y, int(month), day, today)
+	}
+	date, ok := makeDate(today.Year(), int(month), day, today)
+	if ok && date.Before(today) {
+		return makeDate(today.Year()+1, int(month), day, today)
+	}
+	return date, ok
+}
+
+// makeDate rejects dates like 31.02 instead of letting time.Date normalise them.
+func makeDate(year, month, day int, now time.Time) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, now.Location())
+	if date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// Format echoes an interpreted date back to the user, e.g. "Friday, 14 November 2026 at 19:00".
+func Format(result Result, lang string) string {
+	return i18n.Date(lang, result.Date, result.HasTime)
+}
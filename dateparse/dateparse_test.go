@@ -0,0 +1,84 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// Monday, 19 October 2026.
+	now := time.Date(2026, time.October, 19, 15, 4, 0, 0, moscow)
+	date := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, moscow)
+	}
+
+	tests := []struct {
+		text    string
+		want    time.Time
+		hasTime bool
+	}{
+		{"25.12.2026", date(2026, time.December, 25, 0, 0), false},
+		{"25.12.27", date(2027, time.December, 25, 0, 0), false},
+		{"2026-12-25", date(2026, time.December, 25, 0, 0), false},
+		{"25/12", date(2026, time.December, 25, 0, 0), false},
+		{"25 December", date(2026, time.December, 25, 0, 0), false},
+		{"December 25th, 2027", date(2027, time.December, 25, 0, 0), false},
+		{"25 december 2026", date(2026, time.December, 25, 0, 0), false},
+		{"25 декабря в 19", date(2026, time.December, 25, 19, 0), true},
+		{"today", date(2026, time.October, 19, 0, 0), false},
+		{"tomorrow at 7pm", date(2026, time.October, 20, 19, 0), true},
+		{"послезавтра", date(2026, time.October, 21, 0, 0), false},
+		{"next friday 19:00", date(2026, time.October, 23, 19, 0), true},
+		{"Friday 7:30 pm", date(2026, time.October, 23, 19, 30), true},
+		{"в пятницу", date(2026, time.October, 23, 0, 0), false},
+		// The same weekday as today means a week later.
+		{"monday", date(2026, time.October, 26, 0, 0), false},
+		{"in 3 weeks", date(2026, time.November, 9, 0, 0), false},
+		{"in a month", date(2026, time.November, 19, 0, 0), false},
+		{"через 2 дня", date(2026, time.October, 21, 0, 0), false},
+		// Dates without a year that have passed this year roll over to next year.
+		{"15.01", date(2027, time.January, 15, 0, 0), false},
+		{"1 october", date(2027, time.October, 1, 0, 0), false},
+		{"19.10", date(2026, time.October, 19, 0, 0), false},
+		{"  25.12.2026.  ", date(2026, time.December, 25, 0, 0), false},
+		{"25.12.2026 12am", date(2026, time.December, 25, 0, 0), true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.text, now)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %s", tt.text, err)
+			continue
+		}
+		if !got.Date.Equal(tt.want) || got.HasTime != tt.hasTime {
+			t.Errorf("Parse(%q) = %s (time %v), want %s (time %v)", tt.text, got.Date, got.HasTime, tt.want, tt.hasTime)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 15, 4, 0, 0, time.UTC)
+	for _, text := range []string{
+		"",
+		"next week",
+		"at 7pm",
+		"19:00",
+		"31.02.2027",
+		"25.13",
+		"tomorrow 25:00",
+		"tomorrow 13pm",
+		"25 smarch",
+		"someday",
+	} {
+		if got, err := Parse(text, now); err == nil {
+			t.Errorf("Parse(%q) = %s, want an error", text, got.Date)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	result := Result{Date: time.Date(2026, time.November, 13, 19, 0, 0, 0, time.UTC), HasTime: true}
+	if got, want := Format(result, "en"), "Friday, 13 November 2026 at 19:00"; got != want {
+		t.Errorf("Format = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,256 @@
+// Package export packs the club's data into a ZIP archive of CSV and JSON files,
+// sent to admins with /export and served at /clubs/{club}/export.zip.
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"telegram-bot/calendar"
+	"telegram-bot/database"
+	"time"
+)
+
+// Member is the part of a user record worth exporting. Dialog state and chat IDs are left out.
+type Member struct {
+	UserName string `json:"userName"`
+	FullName string `json:"fullName"`
+	IsAdmin  bool   `json:"isAdmin"`
+	Timezone string `json:"timezone,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// FileName returns the name of the archive, e.g. "book-club-export-2026-10-19.zip".
+func FileName(now time.Time) string {
+	return calendar.ClubID() + "-export-" + now.Format("2006-01-02") + ".zip"
+}
+
+// Data is everything the archive holds.
+type Data struct {
+	Members  []Member
+	Books    []database.Book
+	Meetings []database.Meeting
+	RSVPs    []database.RSVP
+	Progress []database.ReadingProgress
+	History  []database.ProgressEvent
+	Ratings  []database.Rating
+	Quotes   []database.Quote
+}
+
+// Fetch reads the club's data for the archive.
+func Fetch() (Data, error) {
+	var data Data
+	users, err := database.FetchUsers()
+	if err != nil {
+		return data, err
+	}
+	for _, user := range users {
+		data.Members = append(data.Members, Member{
+			UserName: user.UserName,
+			FullName: user.FullName,
+			IsAdmin:  user.IsAdmin,
+			Timezone: user.Timezone,
+			Language: user.Language,
+		})
+	}
+	if data.Books, err = database.FetchBooks(); err != nil {
+		return data, err
+	}
+	if data.Meetings, err = database.FetchBookMeetings(data.Books); err != nil {
+		return data, err
+	}
+	if data.RSVPs, err = database.FetchRSVPs(); err != nil {
+		return data, err
+	}
+	if data.Progress, err = database.FetchProgress(); err != nil {
+		return data, err
+	}
+	if data.History, err = database.FetchProgressHistory(); err != nil {
+		return data, err
+	}
+	if data.Ratings, err = database.FetchRatings(); err != nil {
+		return data, err
+	}
+	data.Quotes, err = database.FetchQuotes()
+	return data, err
+}
+
+// Archive builds the ZIP with members, books, meetings and their RSVPs, current
+// progress, the full progress history, ratings and quotes, each as a CSV and a JSON file.
+func Archive() ([]byte, error) {
+	data, err := Fetch()
+	if err != nil {
+		return nil, err
+	}
+	return data.Zip()
+}
+
+// Zip packs the data into the archive.
+func (d Data) Zip() ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data interface{}
+		rows [][]string
+	}{
+		{"members", d.Members, memberRows(d.Members)},
+		{"books", d.Books, bookRows(d.Books)},
+		{"meetings", d.Meetings, meetingRows(d.Meetings)},
+		{"rsvps", d.RSVPs, rsvpRows(d.RSVPs)},
+		{"progress", d.Progress, progressRows(d.Progress)},
+		{"progress_history", d.History, historyRows(d.History)},
+		{"ratings", d.Ratings, ratingRows(d.Ratings)},
+		{"quotes", d.Quotes, quoteRows(d.Quotes)},
+	}
+	for _, file := range files {
+		if err := writeCSV(archive, file.name+".csv", file.rows); err != nil {
+			return nil, err
+		}
+		if err := writeJSON(archive, file.name+".json", file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Handler serves the archive at /clubs/{club}/export.zip to callers presenting
+// EXPORT_TOKEN, either as "Authorization: Bearer <token>" or as ?token=.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	token := os.Getenv("EXPORT_TOKEN")
+	given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if given == "" {
+		given = r.URL.Query().Get("token")
+	}
+	if r.PathValue("club") != calendar.ClubID() || token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+	data, err := Archive()
+	if err != nil {
+		log.Printf("Failed to build export: %s", err)
+		http.Error(w, "Failed to build export", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+FileName(time.Now())+`"`)
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Failed to write export: %s", err)
+	}
+}
+
+func writeCSV(archive *zip.Writer, name string, rows [][]string) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func writeJSON(archive *zip.Writer, name string, data interface{}) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+func memberRows(members []Member) [][]string {
+	rows := [][]string{{"UserName", "FullName", "IsAdmin", "Timezone", "Language"}}
+	for _, m := range members {
+		rows = append(rows, []string{m.UserName, m.FullName, strconv.FormatBool(m.IsAdmin), m.Timezone, m.Language})
+	}
+	return rows
+}
+
+func bookRows(books []database.Book) [][]string {
+	rows := [][]string{{"BookID", "Title", "Author", "Active", "Queued", "MeetingDate", "ISBN", "Pages", "AudioMinutes", "Genre", "Year", "StartedAt", "FinishedAt"}}
+	for _, b := range books {
+		var pages []string
+		for _, edition := range b.Editions {
+			pages = append(pages, strconv.Itoa(edition.Pages))
+		}
+		rows = append(rows, []string{
+			b.BookID, b.Title, b.Author, strconv.FormatBool(b.Active), strconv.FormatBool(b.Queued),
+			b.MeetingDate, b.ISBN, strings.Join(pages, ";"), strconv.Itoa(b.AudioMinutes), b.Genre,
+			strconv.Itoa(b.Year), formatTime(b.Started()), formatTime(b.FinishedAt),
+		})
+	}
+	return rows
+}
+
+func meetingRows(meetings []database.Meeting) [][]string {
+	rows := [][]string{{"MeetingID", "BookID", "Title", "Date", "Time", "Timezone", "Location", "Link", "Agenda"}}
+	for _, m := range meetings {
+		rows = append(rows, []string{m.MeetingID, m.BookID, m.Title, m.Date, m.Time, m.Timezone, m.Location, m.Link, m.Agenda})
+	}
+	return rows
+}
+
+func rsvpRows(rsvps []database.RSVP) [][]string {
+	rows := [][]string{{"MeetingID", "UserName", "Status"}}
+	for _, r := range rsvps {
+		rows = append(rows, []string{r.MeetingID, r.UserName, string(r.Status)})
+	}
+	return rows
+}
+
+func progressRows(progress []database.ReadingProgress) [][]string {
+	rows := [][]string{{"UserName", "BookID", "Type", "Progress", "PageNumber", "TotalPages", "Location", "TotalLocations", "ListenedMinutes", "TotalMinutes"}}
+	for _, p := range progress {
+		rows = append(rows, []string{
+			p.UserName, p.BookID, string(p.Type), strconv.Itoa(p.Progress),
+			strconv.Itoa(p.PageNumber), strconv.Itoa(p.TotalPages),
+			strconv.Itoa(p.Location), strconv.Itoa(p.TotalLocations),
+			strconv.Itoa(p.ListenedMinutes), strconv.Itoa(p.TotalMinutes),
+		})
+	}
+	return rows
+}
+
+func historyRows(events []database.ProgressEvent) [][]string {
+	rows := [][]string{{"UserName", "Timestamp", "BookID", "Progress"}}
+	for _, e := range events {
+		rows = append(rows, []string{e.UserName, formatTime(e.Timestamp), e.BookID, strconv.Itoa(e.Progress)})
+	}
+	return rows
+}
+
+func ratingRows(ratings []database.Rating) [][]string {
+	rows := [][]string{{"BookID", "UserName", "Rating", "Review"}}
+	for _, r := range ratings {
+		rows = append(rows, []string{r.BookID, r.UserName, strconv.Itoa(r.Rating), r.Review})
+	}
+	return rows
+}
+
+func quoteRows(quotes []database.Quote) [][]string {
+	rows := [][]string{{"QuoteID", "BookID", "UserName", "Text", "Reference"}}
+	for _, q := range quotes {
+		rows = append(rows, []string{q.QuoteID, q.BookID, q.UserName, q.Text, q.Reference})
+	}
+	return rows
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"telegram-bot/database"
+	"testing"
+)
+
+func TestZip(t *testing.T) {
+	data := Data{
+		Members: []Member{{UserName: "alice", FullName: "Alice"}},
+		Books:   []database.Book{{BookID: "1", Title: "Anna Karenina", Author: "Leo Tolstoy"}},
+		Ratings: []database.Rating{{BookID: "1", UserName: "alice", Rating: 5, Review: "Loved it"}},
+		Quotes:  []database.Quote{{BookID: "1", QuoteID: "2", UserName: "alice", Text: "All happy families are alike, each unhappy family is unhappy in its own way.", Reference: "p. 1"}},
+	}
+	archive, err := data.Zip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+		files[file.Name] = file
+	}
+	want := []string{
+		"members.csv", "members.json", "books.csv", "books.json",
+		"meetings.csv", "meetings.json", "rsvps.csv", "rsvps.json",
+		"progress.csv", "progress.json", "progress_history.csv", "progress_history.json",
+		"ratings.csv", "ratings.json", "quotes.csv", "quotes.json",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("files = %v, want %v", names, want)
+	}
+
+	headers := map[string][]string{
+		"members.csv":          {"UserName", "FullName", "IsAdmin", "Timezone", "Language"},
+		"rsvps.csv":            {"MeetingID", "UserName", "Status"},
+		"progress_history.csv": {"UserName", "Timestamp", "BookID", "Progress"},
+		"ratings.csv":          {"BookID", "UserName", "Rating", "Review"},
+		"quotes.csv":           {"QuoteID", "BookID", "UserName", "Text", "Reference"},
+	}
+	for name, header := range headers {
+		rows := readCSV(t, files[name])
+		if !reflect.DeepEqual(rows[0], header) {
+			t.Errorf("%s header = %v, want %v", name, rows[0], header)
+		}
+	}
+	quotes := readCSV(t, files["quotes.csv"])
+	if len(quotes) != 2 || quotes[1][3] != data.Quotes[0].Text {
+		t.Errorf("quotes.csv = %v, want the quote with its comma intact", quotes)
+	}
+}
+
+func readCSV(t *testing.T, file *zip.File) [][]string {
+	t.Helper()
+	content, err := file.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer content.Close()
+	rows, err := csv.NewReader(content).ReadAll()
+	if err != nil {
+		t.Fatalf("%s: %s", file.Name, err)
+	}
+	return rows
+}
@@ -0,0 +1,240 @@
+// Package gamification computes reading streaks, badges and the club leaderboard
+// from the progress history.
+package gamification
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+	"time"
+)
+
+// bookCountBadges are the numbers of finished books that earn a badge.
+var bookCountBadges = []int{1, 5, 10, 25, 50}
+
+// Stats is what a member has achieved across all books.
+type Stats struct {
+	UserName      string
+	FullName      string
+	BooksFinished int
+	Streak        int // consecutive days with a progress update, up to today
+	BestStreak    int
+	Badges        []string // in the language ClubStats was asked for
+}
+
+// Streaks returns the current and the longest run of consecutive days with at least
+// one update. A streak is still current if the last update was yesterday.
+func Streaks(events []database.ProgressEvent, location *time.Location, now time.Time) (int, int) {
+	days := map[string]bool{}
+	for _, event := range events {
+		days[event.Timestamp.In(location).Format("2006-01-02")] = true
+	}
+	var sorted []time.Time
+	for day := range days {
+		date, _ := time.ParseInLocation("2006-01-02", day, location)
+		sorted = append(sorted, date)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	best, run := 0, 0
+	for i, day := range sorted {
+		if i > 0 && utils.DaysBetween(sorted[i-1], day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > best {
+			best = run
+		}
+	}
+	current := 0
+	if len(sorted) > 0 && utils.DaysBetween(sorted[len(sorted)-1], now.In(location)) <= 1 {
+		current = run
+	}
+	return current, best
+}
+
+// ClubStats computes the stats of every member, best first, with badges named in lang.
+func ClubStats(lang string) []Stats {
+	now := time.Now()
+	clubLocation := database.ClubLocation()
+	history := database.ProgressHistory()
+	books := database.BookList()
+	meetings := pastMeetings(now)
+
+	progressByBook := map[string][]database.ReadingProgress{}
+	for _, progress := range database.ProgressList() {
+		progressByBook[progress.BookID] = append(progressByBook[progress.BookID], progress)
+	}
+
+	eventsByUser := map[string][]database.ProgressEvent{}
+	for _, event := range history {
+		eventsByUser[event.UserName] = append(eventsByUser[event.UserName], event)
+	}
+
+	finished := map[string]int{}
+	firstToFinish := map[string]int{}
+	earlyFinisher := map[string]int{}
+	for _, book := range books {
+		if book.Queued {
+			continue
+		}
+		for _, progress := range progressByBook[book.BookID] {
+			if progress.Progress >= 100 {
+				finished[progress.UserName]++
+			}
+		}
+		// The history is sorted, so the first 100% update of each member is when they finished.
+		finishedAt := map[string]time.Time{}
+		first := ""
+		for _, event := range history {
+			if event.BookID != book.BookID || event.Progress < 100 {
+				continue
+			}
+			if _, ok := finishedAt[event.UserName]; ok {
+				continue
+			}
+			finishedAt[event.UserName] = event.Timestamp
+			if first == "" {
+				first = event.UserName
+			}
+		}
+		if first != "" {
+			firstToFinish[first]++
+		}
+		if meetingDate, err := utils.ParseDate(book.MeetingDate, clubLocation); err == nil {
+			for user, at := range finishedAt {
+				if utils.DaysBetween(at.In(clubLocation), meetingDate) >= 0 {
+					earlyFinisher[user]++
+				}
+			}
+		}
+	}
+
+	var stats []Stats
+	for _, user := range database.UserList() {
+		events := eventsByUser[user.UserName]
+		streak, best := Streaks(events, database.UserLocation(user.UserName), now)
+		s := Stats{
+			UserName:      user.UserName,
+			FullName:      user.FullName,
+			BooksFinished: finished[user.UserName],
+			Streak:        streak,
+			BestStreak:    best,
+		}
+		s.Badges = badges(s, firstToFinish[user.UserName], earlyFinisher[user.UserName], neverMissed(user.UserName, events, meetings), lang)
+		stats = append(stats, s)
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].BooksFinished != stats[j].BooksFinished {
+			return stats[i].BooksFinished > stats[j].BooksFinished
+		}
+		if len(stats[i].Badges) != len(stats[j].Badges) {
+			return len(stats[i].Badges) > len(stats[j].Badges)
+		}
+		return stats[i].Streak > stats[j].Streak
+	})
+	return stats
+}
+
+func badges(s Stats, firstToFinish, earlyFinisher int, neverMissed bool, lang string) []string {
+	var result []string
+	if firstToFinish > 0 {
+		result = append(result, counted(i18n.T(lang, "First to finish"), firstToFinish))
+	}
+	if earlyFinisher > 0 {
+		result = append(result, counted(i18n.T(lang, "Finished before the meeting"), earlyFinisher))
+	}
+	for i := len(bookCountBadges) - 1; i >= 0; i-- {
+		if s.BooksFinished >= bookCountBadges[i] {
+			if bookCountBadges[i] == 1 {
+				result = append(result, i18n.T(lang, "First book"))
+			} else {
+				result = append(result, i18n.N(lang, bookCountBadges[i], "%d book", "%d books", bookCountBadges[i]))
+			}
+			break
+		}
+	}
+	if neverMissed {
+		result = append(result, i18n.T(lang, "Never missed a meeting"))
+	}
+	return result
+}
+
+func counted(badge string, count int) string {
+	if count == 1 {
+		return badge
+	}
+	return fmt.Sprintf("%s ×%d", badge, count)
+}
+
+type pastMeeting struct {
+	start time.Time
+	going map[string]bool
+}
+
+func pastMeetings(now time.Time) []pastMeeting {
+	going := map[string]map[string]bool{}
+	for _, rsvp := range database.RSVPList() {
+		if going[rsvp.MeetingID] == nil {
+			going[rsvp.MeetingID] = map[string]bool{}
+		}
+		going[rsvp.MeetingID][rsvp.UserName] = rsvp.Status == database.Going
+	}
+	var result []pastMeeting
+	for _, meeting := range database.MeetingList() {
+		start, err := meeting.Start()
+		if err != nil || !start.Before(now) {
+			continue
+		}
+		result = append(result, pastMeeting{start: start, going: going[meeting.MeetingID]})
+	}
+	return result
+}
+
+// neverMissed reports whether the member said they were going to every meeting
+// held since their first progress update, and there was at least one.
+func neverMissed(userName string, events []database.ProgressEvent, meetings []pastMeeting) bool {
+	if len(events) == 0 {
+		return false
+	}
+	joined := events[0].Timestamp
+	attended := 0
+	for _, meeting := range meetings {
+		if meeting.start.Before(joined) {
+			continue
+		}
+		if !meeting.going[userName] {
+			return false
+		}
+		attended++
+	}
+	return attended > 0
+}
+
+// Leaderboard formats the club's stats for /leaderboard.
+func Leaderboard(lang string) string {
+	stats := ClubStats(lang)
+	if len(stats) == 0 {
+		return i18n.T(lang, "There are no members yet.")
+	}
+	result := i18n.T(lang, "Leaderboard:") + "\n"
+	for i, s := range stats {
+		result += fmt.Sprintf("\n%d. %s - ", i+1, s.FullName) + i18n.N(lang, s.BooksFinished, "%d book finished", "%d books finished", s.BooksFinished)
+		if s.Streak > 0 {
+			result += ", " + i18n.N(lang, s.Streak, "streak %d day", "streak %d days", s.Streak)
+		}
+		if s.BestStreak > 0 {
+			result += " " + i18n.T(lang, "(best %d)", s.BestStreak)
+		}
+		if len(s.Badges) > 0 {
+			result += "\n   " + i18n.T(lang, "Badges: ") + strings.Join(s.Badges, ", ")
+		}
+		result += "\n"
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package gamification
+
+import (
+	"telegram-bot/database"
+	"testing"
+	"time"
+)
+
+func TestStreaks(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, moscow)
+	day := func(daysAgo, hour int) database.ProgressEvent {
+		at := time.Date(2026, time.October, 19-daysAgo, hour, 0, 0, 0, moscow)
+		return database.ProgressEvent{UserName: "alice", Timestamp: at.UTC()}
+	}
+
+	tests := []struct {
+		name          string
+		events        []database.ProgressEvent
+		current, best int
+	}{
+		{"no updates", nil, 0, 0},
+		{"today only", []database.ProgressEvent{day(0, 9)}, 1, 1},
+		{"several updates a day count once", []database.ProgressEvent{day(0, 9), day(0, 10), day(1, 9)}, 2, 2},
+		{"still current if the last update was yesterday", []database.ProgressEvent{day(1, 9), day(2, 9), day(3, 9)}, 3, 3},
+		{"broken two days ago", []database.ProgressEvent{day(2, 9), day(3, 9)}, 0, 2},
+		{"longest run in the past", []database.ProgressEvent{day(0, 9), day(5, 9), day(6, 9), day(7, 9)}, 1, 3},
+		{"unsorted input", []database.ProgressEvent{day(0, 9), day(2, 9), day(1, 9)}, 3, 3},
+		// 01:00 in Moscow is still the previous day in UTC; days follow the club's timezone.
+		{"days in the club timezone", []database.ProgressEvent{day(0, 1), day(1, 23)}, 2, 2},
+	}
+	for _, tt := range tests {
+		current, best := Streaks(tt.events, moscow, now)
+		if current != tt.current || best != tt.best {
+			t.Errorf("%s: Streaks = %d, %d, want %d, %d", tt.name, current, best, tt.current, tt.best)
+		}
+	}
+}
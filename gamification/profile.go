@@ -0,0 +1,94 @@
+package gamification
+
+import (
+	"telegram-bot/database"
+	"telegram-bot/utils"
+	"time"
+)
+
+// Profile is a member's reading record with the club.
+type Profile struct {
+	BooksStarted    int
+	BooksFinished   int
+	PercentPerDay   float64 // average pace while reading, 0 if unknown
+	FavouriteFormat database.BookType
+	RatingsGiven    int
+	AverageRating   float64
+	Streak          int
+	BestStreak      int
+}
+
+// CompletionRate is the share of started books the member finished, in percent.
+func (p Profile) CompletionRate() int {
+	return utils.Percent(p.BooksFinished, p.BooksStarted)
+}
+
+func MemberProfile(userName string) Profile {
+	var profile Profile
+	formats := map[database.BookType]int{}
+	var ratings []database.Rating
+	for _, book := range database.BookList() {
+		if book.Queued {
+			continue
+		}
+		for _, progress := range database.BookProgress(book.BookID) {
+			if progress.UserName != userName {
+				continue
+			}
+			profile.BooksStarted++
+			if progress.Progress >= 100 {
+				profile.BooksFinished++
+			}
+			if progress.Type != "" {
+				formats[progress.Type]++
+			}
+		}
+		for _, rating := range database.BookRatings(book.BookID) {
+			if rating.UserName == userName {
+				ratings = append(ratings, rating)
+			}
+		}
+	}
+	// Ties go to the format offered first, so the profile doesn't change between calls.
+	for _, format := range database.BookTypes {
+		if formats[format] > formats[profile.FavouriteFormat] {
+			profile.FavouriteFormat = format
+		}
+	}
+	profile.AverageRating, profile.RatingsGiven = database.AverageRating(ratings)
+
+	var events []database.ProgressEvent
+	for _, event := range database.ProgressHistory() {
+		if event.UserName == userName {
+			events = append(events, event)
+		}
+	}
+	profile.Streak, profile.BestStreak = Streaks(events, database.UserLocation(userName), time.Now())
+	profile.PercentPerDay = pace(events)
+	return profile
+}
+
+// pace is the percent gained per day between the first and the last update of each book.
+func pace(events []database.ProgressEvent) float64 {
+	first := map[string]database.ProgressEvent{}
+	last := map[string]database.ProgressEvent{}
+	for _, event := range events {
+		if _, ok := first[event.BookID]; !ok {
+			first[event.BookID] = event
+		}
+		last[event.BookID] = event
+	}
+	gained, days := 0, 0
+	for bookID, start := range first {
+		end := last[bookID]
+		if end.Progress <= start.Progress {
+			continue
+		}
+		gained += end.Progress - start.Progress
+		days += max(1, utils.DaysBetween(start.Timestamp, end.Timestamp))
+	}
+	if days == 0 {
+		return 0
+	}
+	return float64(gained) / float64(days)
+}
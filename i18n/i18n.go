@@ -0,0 +1,104 @@
+// Package i18n translates the bot's messages. Messages are looked up by their English
+// text, so code stays readable and a missing translation falls back to English.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	English = "en"
+	Russian = "ru"
+)
+
+// Default is the language used when neither the member nor the club has chosen one.
+const Default = English
+
+// catalogues maps a language to its translations of the English messages.
+var catalogues = map[string]map[string]string{
+	Russian: russian,
+}
+
+// pluralCatalogues maps a language to the plural forms of English singular messages.
+var pluralCatalogues = map[string]map[string][]string{
+	Russian: russianPlurals,
+}
+
+// Supported reports whether lang has a catalogue (English always does).
+func Supported(lang string) bool {
+	_, ok := catalogues[lang]
+	return ok || lang == English
+}
+
+// FromLanguageCode maps a Telegram language code such as "ru" or "en-US" to a
+// supported language, or "" if there is none.
+func FromLanguageCode(code string) string {
+	lang, _, _ := strings.Cut(strings.ToLower(code), "-")
+	if Supported(lang) {
+		return lang
+	}
+	return ""
+}
+
+// T translates message into lang and formats it with args like fmt.Sprintf.
+func T(lang, message string, args ...interface{}) string {
+	if translated, ok := catalogues[lang][message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// N picks the plural form of a message for n, e.g. N(lang, n, "%d day", "%d days", n).
+// English messages have two forms; other languages take theirs from the catalogue.
+func N(lang string, n int, singular, plural string, args ...interface{}) string {
+	message := plural
+	if forms, ok := pluralCatalogues[lang][singular]; ok {
+		message = forms[pluralForm(lang, n)]
+	} else if n == 1 || n == -1 {
+		message = singular
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// pluralForm returns the index of the plural form for n in the catalogue of lang.
+func pluralForm(lang string, n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case Russian:
+		// one: 1, 21, 31...; few: 2-4, 22-24...; many: everything else.
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return 0
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+			return 1
+		default:
+			return 2
+		}
+	}
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
+
+// Date formats a date like "Friday, 14 November 2026", adding the time if withTime is set.
+func Date(lang string, t time.Time, withTime bool) string {
+	text := t.Format("Monday, 2 January 2006")
+	if lang == Russian {
+		text = fmt.Sprintf("%s, %d %s %d", russianWeekdays[t.Weekday()], t.Day(), russianMonths[t.Month()-1], t.Year())
+	}
+	if withTime {
+		text += T(lang, " at %s", t.Format("15:04"))
+	}
+	return text
+}
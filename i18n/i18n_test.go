@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPluralForm(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want int
+	}{
+		{English, 0, 1},
+		{English, 1, 0},
+		{English, 2, 1},
+		{English, -1, 0},
+		{Russian, 1, 0},
+		{Russian, 21, 0},
+		{Russian, 101, 0},
+		{Russian, 2, 1},
+		{Russian, 4, 1},
+		{Russian, 23, 1},
+		{Russian, 0, 2},
+		{Russian, 5, 2},
+		{Russian, 11, 2},
+		{Russian, 12, 2},
+		{Russian, 14, 2},
+		{Russian, 111, 2},
+		{Russian, 112, 2},
+		{Russian, -2, 1},
+	}
+	for _, tt := range tests {
+		if got := pluralForm(tt.lang, tt.n); got != tt.want {
+			t.Errorf("pluralForm(%s, %d) = %d, want %d", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{English, 1, "1 day"},
+		{English, 5, "5 days"},
+		{Russian, 1, "1 день"},
+		{Russian, 3, "3 дня"},
+		{Russian, 11, "11 дней"},
+		{Russian, 22, "22 дня"},
+		// Languages without a catalogue fall back to the English forms.
+		{"de", 2, "2 days"},
+	}
+	for _, tt := range tests {
+		if got := N(tt.lang, tt.n, "%d day", "%d days", tt.n); got != tt.want {
+			t.Errorf("N(%s, %d) = %q, want %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+	if got := N(English, 2, "one book", "many books"); got != "many books" {
+		t.Errorf("N without args = %q, want the plain plural", got)
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(Russian, "Thank you!"); got != "Спасибо!" {
+		t.Errorf("T(ru, Thank you!) = %q", got)
+	}
+	if got := T(Russian, "no such message %d", 5); got != "no such message 5" {
+		t.Errorf("untranslated message = %q, want the formatted English", got)
+	}
+	if got := T(English, "100%"); got != "100%" {
+		t.Errorf("message without args = %q, want it unformatted", got)
+	}
+}
+
+func TestFromLanguageCode(t *testing.T) {
+	for code, want := range map[string]string{"ru": Russian, "en-US": English, "EN": English, "de": "", "": ""} {
+		if got := FromLanguageCode(code); got != want {
+			t.Errorf("FromLanguageCode(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	date := time.Date(2026, time.November, 13, 19, 30, 0, 0, time.UTC)
+	if got, want := Date(English, date, true), "Friday, 13 November 2026 at 19:30"; got != want {
+		t.Errorf("Date(en) = %q, want %q", got, want)
+	}
+	if got := Date(Russian, date, false); got == Date(English, date, false) {
+		t.Errorf("Date(ru) = %q, want it in Russian", got)
+	}
+}
+
+func TestRussianPluralsHaveThreeForms(t *testing.T) {
+	for singular, forms := range russianPlurals {
+		if len(forms) != 3 {
+			t.Errorf("%q has %d Russian plural forms, want 3", singular, len(forms))
+		}
+	}
+}
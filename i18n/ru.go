@@ -0,0 +1,372 @@
+package i18n
+
+var russianWeekdays = [...]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"}
+
+var russianMonths = [...]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"}
+
+var russian = map[string]string{
+	" at %s": " в %s",
+	"You are not authorized to use this command.":                            "У вас нет прав на эту команду.",
+	"I don't recognize that command. Use /help to see the list of commands.": "Я не знаю такой команды. Список команд — /help.",
+	"Here are the commands you can use: ":                                    "Доступные команды: ",
+	"Here is the list of users: ":                                            "Список участников: ",
+	"The current book is: %s by %s (id - %s) ":                               "Текущая книга: %s, автор %s (id - %s) ",
+	"Meeting date is %s":                                                     "Встреча %s",
+	" (today)":                                                               " (сегодня)",
+	" (tomorrow)":                                                            " (завтра)",
+	"Milestone: %s by %s":                                                    "Этап: %s до %s",
+	"Chapters: %d (report with /chapter)":                                    "Глав: %d (отметить прогресс — /chapter)",
+	"Genre: ":                                                                "Жанр: ",
+	"Year: ":                                                                 "Год: ",
+	"Edition: ":                                                              "Издание: ",
+	"Audiobook: ":                                                            "Аудиокнига: ",
+	"Club rating: ":                                                          "Оценка клуба: ",
+	"Cover: ":                                                                "Обложка: ",
+	"%s by %s":                                                               "%s, автор %s",
+	" (current)":                                                             " (текущая)",
+	" (queued)":                                                              " (в очереди)",
+	"Here is the list of books: ":                                            "Список книг: ",
+	"page %d/%d":                                                             "страница %d/%d",
+	"location %d/%d":                                                         "позиция %d/%d",
+	"No active book found.":                                                  "Текущая книга не выбрана.",
+	"Next milestone: %s (%d%%) by %s":                                        "Следующий этап: %s (%d%%) до %s",
+	"Not there yet: ":                                                        "Ещё не дошли: ",
+	"No users have set their progress yet.":                                  "Пока никто не отметил свой прогресс.",
+	"want to read":                                                           "хочу прочитать",
+	"reading":                                                                "читаю",
+	"read":                                                                   "прочитано",
+	"There are no upcoming meetings.":                                        "Ближайших встреч нет.",
+	"Going":                                                                  "Приду",
+	"Maybe":                                                                  "Возможно",
+	"Not going":                                                              "Не приду",
+	"Final meeting":                                                          "Итоговая встреча",
+	"Halfway discussion":                                                     "Обсуждение середины книги",
+	"Place: ":                                                                "Место: ",
+	"Link: ":                                                                 "Ссылка: ",
+	"Agenda: ":                                                               "Повестка: ",
+	"Nobody has answered yet.":                                               "Пока никто не ответил.",
+	"Subscribe to all club meetings: ":                                       "Подписаться на все встречи клуба: ",
+	"Your answer: ":                                                          "Ваш ответ: ",
+	"The club hasn't finished any books yet.":                        "Клуб ещё не дочитал ни одной книги.",
+	"Please enter the ID or the title of one book, e.g. /history %s": "Укажите ID или название одной книги, например /history %s",
+	"Books the club has read:":                                       "Книги, прочитанные клубом:",
+	", meeting %s":                                                   ", встреча %s",
+	"Finished: %d of %d members":                                     "Дочитали: %d из %d участников",
+	"Rating: ":                                                       "Оценка: ",
+	"Use /history <book ID> to see the final group progress of a book.": "Итоговый прогресс по книге — /history <ID книги>.",
+	"Meeting date was %s": "Встреча была %s",
+	"Quotes:":             "Цитаты:",
+	"No such book. Use /history to find the book ID.":  "Такой книги нет. ID книги можно найти в /history.",
+	"How would you rate \"%s\" from 1 to 5?":           "Как вы оцените «%s» по шкале от 1 до 5?",
+	"Later":                                            "Позже",
+	"Skip":                                             "Пропустить",
+	"OK, you can rate it later with /rate.":            "Хорошо, оценить книгу можно позже командой /rate.",
+	"Please enter a number from 1 to 5.":               "Введите число от 1 до 5.",
+	"Thank you! Would you like to add a short review?": "Спасибо! Хотите добавить короткий отзыв?",
+	"Thank you for your feedback!":                     "Спасибо за отзыв!",
+	"No spoilers":                                      "Без спойлеров",
+	"Which part of the book does it refer to? Enter a page (e.g. page 120) or a percent (e.g. 45%). Members who haven't got there yet won't see it.": "К какой части книги это относится? Введите страницу (например, стр. 120) или процент (например, 45%). Участники, которые ещё не дочитали до этого места, его не увидят.",
+	"Enter the text:":         "Введите текст:",
+	"Please enter some text.": "Введите текст.",
+	"Something went wrong. Please start again with /addNote or /addQuestion.":            "Что-то пошло не так. Начните заново с /addNote или /addQuestion.",
+	"Thank you! Members can see it with /questions.":                                     "Спасибо! Участники увидят это в /questions.",
+	"Please enter a percent from 1 to 100.":                                              "Введите процент от 1 до 100.",
+	"Sorry, I didn't understand you. ":                                                   "Извините, я вас не понял. ",
+	"I don't know the page count of the book. Please enter a percent instead, e.g. 45%.": "Я не знаю, сколько в книге страниц. Введите процент, например 45%.",
+	"Please enter a page from 1 to %d.":                                                  "Введите страницу от 1 до %d.",
+	"page %d":                                                                            "стр. %d",
+	"There are no questions or notes yet. Add one with /addQuestion or /addNote.":        "Вопросов и заметок пока нет. Добавьте их через /addQuestion или /addNote.",
+	"Questions and notes for %s:":                                                        "Вопросы и заметки к «%s»:",
+	"Note":                                                                               "Заметка",
+	"Question":                                                                           "Вопрос",
+	", by %s":                                                                            ", от %s",
+	" (ahead of you)":                                                                    " (дальше, чем вы прочитали)",
+	"regular":                                                                            "бумажная книга",
+	"ebook":                                                                              "электронная книга",
+	"kindle":                                                                             "Kindle",
+	"audio":                                                                              "аудиокнига",
+	"Audio Book":                                                                         "Аудиокнига",
+	"E-book":                                                                             "Электронная книга",
+	"Regular Book":                                                                       "Бумажная книга",
+	"Enter how much you have listened so far (hh:mm):":                                                "Сколько вы уже прослушали (чч:мм)?",
+	"Enter percent of the book you have read:":                                                        "Введите, сколько процентов книги вы прочитали:",
+	"Enter the page you are currently reading:":                                                       "Введите страницу, которую вы сейчас читаете:",
+	"Enter total Kindle locations of the book:":                                                       "Введите общее количество позиций Kindle в книге:",
+	"Enter total duration of the audiobook (hh:mm):":                                                  "Введите общую длительность аудиокниги (чч:мм):",
+	"Enter total pages of the book:":                                                                  "Введите количество страниц в книге:",
+	"Enter your current Kindle location:":                                                             "Введите текущую позицию Kindle:",
+	"Please enter a number between 0 and 100.":                                                        "Введите число от 0 до 100.",
+	"Please enter a number greater than 0.":                                                           "Введите число больше 0.",
+	"Please enter a number less than or equal to the total number of locations - %d.":                 "Введите число, не превышающее общее количество позиций — %d.",
+	"Please enter a number less than or equal to the total number of pages - %d.":                     "Введите число, не превышающее количество страниц — %d.",
+	"Please enter a number.":                                                                          "Введите число.",
+	"Please enter a time less than or equal to the total duration - %s.":                              "Введите время, не превышающее общую длительность — %s.",
+	"Please enter the duration in format hh:mm, e.g. 09:45.":                                          "Введите длительность в формате чч:мм, например 09:45.",
+	"Please enter the time in format hh:mm, e.g. 02:30.":                                              "Введите время в формате чч:мм, например 02:30.",
+	"Please select a chapter on the keyboard.":                                                        "Выберите главу на клавиатуре.",
+	"Select the book's type (regular, e-book, kindle or audio):":                                      "Выберите формат книги (бумажная, электронная, Kindle или аудио):",
+	"Select your edition or enter total pages of the book:":                                           "Выберите своё издание или введите количество страниц в книге:",
+	"Sorry, I didn't understand you. Please select the book type - regular, e-book, kindle or audio:": "Извините, я вас не понял. Выберите формат книги — бумажная, электронная, Kindle или аудио:",
+	"Thank you for updating your audiobook progress!":                                                 "Спасибо, что обновили прогресс по аудиокниге!",
+	"Thank you for updating your e-book progress!":                                                    "Спасибо, что обновили прогресс по электронной книге!",
+	"Thank you!":                           "Спасибо!",
+	"Thank you! Your progress is now %d%%": "Спасибо! Ваш прогресс теперь %d%%",
+	"The chapters of this book are not set yet. Use /setProgress instead.":                                                     "Главы этой книги пока не заданы. Используйте /setProgress.",
+	"Total pages updated. Your progress is now page %d/%d (%d%%).":                                                             "Количество страниц обновлено. Ваш прогресс теперь стр. %d/%d (%d%%).",
+	"Which chapter have you finished?":                                                                                         "Какую главу вы дочитали?",
+	"You are already reading this format. Use /setProgress to update your progress.":                                           "Вы уже читаете в этом формате. Обновите прогресс через /setProgress.",
+	"You are currently reading the %s version. Select the new format:":                                                         "Сейчас у вас %s. Выберите новый формат:",
+	"You are not reading a regular book. Use /setProgress to choose your format or /changeFormat to switch to a regular book.": "Вы читаете не бумажную книгу. Выберите формат через /setProgress или перейдите на бумажную книгу через /changeFormat.",
+	"You haven't set your progress for the current book yet. Use /setProgress first.":                                          "Вы ещё не отметили прогресс по текущей книге. Сначала используйте /setProgress.",
+	"You need to listen %s per day to finish the audiobook by the meeting date %s.":                                            "Чтобы дослушать аудиокнигу к встрече %[2]s, нужно слушать по %[1]s в день.",
+	"You need to read %.1f locations per day to finish the book by the meeting date %s.":                                       "Чтобы дочитать книгу к встрече %[2]s, нужно читать по %[1].1f позиций в день.",
+	"You need to read %.1f%% of the book per day to finish it by the meeting date %s.":                                         "Чтобы дочитать книгу к встрече %[2]s, нужно читать по %[1].1f%% книги в день.",
+	"Your progress was converted: %s → %s.":                                                                                    "Ваш прогресс пересчитан: %s → %s.",
+	"Your reading format is now %s.":                                                                                           "Теперь ваш формат — %s.",
+	"Send me the passage you want to save:":                                                                                    "Пришлите отрывок, который хотите сохранить:",
+	"Please send the text of the quote.":                                                                                       "Пришлите текст цитаты.",
+	"Enter the page or chapter, e.g. p. 120 or Chapter 3:":                                                                     "Введите страницу или главу, например стр. 120 или Глава 3:",
+	"The quote is saved. See all quotes with /quotes.":                                                                         "Цитата сохранена. Все цитаты — в /quotes.",
+	"No such book. Use /getBookList to find the book ID.":                                                                      "Такой книги нет. ID книги можно найти в /getBookList.",
+	"There are no quotes from \"%s\" yet. Save one with /quote.":                                                               "Цитат из «%s» пока нет. Сохраните первую через /quote.",
+	"Quotes from \"%s\":":                            "Цитаты из «%s»:",
+	"There are no quotes yet. Save one with /quote.": "Цитат пока нет. Сохраните первую через /quote.",
+	"added by %s":                                    "добавил(а) %s",
+	"Want to read":                                   "Хочу прочитать",
+	"Reading":                                        "Читаю",
+	"Read":                                           "Прочитано",
+	"Update progress":                                "Обновить прогресс",
+	"Nominate for the club":                          "Предложить клубу",
+	"Withdraw nomination":                            "Отозвать предложение",
+	"Remove":                                         "Удалить",
+	"Enter the title of the book:":                   "Введите название книги:",
+	"Please enter the title of the book.":            "Введите название книги.",
+	"Enter the author:":                              "Введите автора:",
+	"Which shelf should it go on?":                   "На какую полку её поставить?",
+	"Please select a shelf on the keyboard.":         "Выберите полку на клавиатуре.",
+	"\"%s\" is on your %s shelf. See your shelf with /shelf.": "«%s» теперь на полке %s. Ваша полка — /shelf.",
+	"Your shelf is empty. Add a book with /addToShelf.":       "Ваша полка пуста. Добавьте книгу через /addToShelf.",
+	"Your shelf:": "Ваша полка:",
+	"Use /updateShelf to track progress, move or nominate a book.": "Через /updateShelf можно отметить прогресс, переложить или предложить книгу.",
+	"nominated":                             "предложена",
+	"Which book?":                           "Какая книга?",
+	"Please select a book on the keyboard.": "Выберите книгу на клавиатуре.",
+	"What do you want to do?":               "Что вы хотите сделать?",
+	"\"%s\" moved to your %s shelf.":        "«%s» перенесена на полку %s.",
+	"Thank you! \"%s\" is nominated for the club. Everybody can see the nominations with /nominations.": "Спасибо! «%s» предложена клубу. Все предложения — в /nominations.",
+	"The nomination is withdrawn.":                                                     "Предложение отозвано.",
+	"\"%s\" is removed from your shelf.":                                               "«%s» убрана с полки.",
+	"Please select an action on the keyboard.":                                         "Выберите действие на клавиатуре.",
+	"Thank you! You have read %d%% of \"%s\".":                                         "Спасибо! Вы прочитали %d%% книги «%s».",
+	"It is now on your read shelf.":                                                    "Теперь она на полке «Прочитано».",
+	"There are no nominations yet. Nominate a book from your shelf with /updateShelf.": "Предложений пока нет. Предложите книгу со своей полки через /updateShelf.",
+	"Nominations for the club:":                                                        "Предложения для клуба:",
+	"nominated by %s":                                                                  "предложил(а) %s",
+	"Admins can add a nominated book to the queue with /queueBook.":                    "Администраторы могут добавить предложенную книгу в очередь через /queueBook.",
+	"Display name":                 "Имя",
+	"Timezone":                     "Часовой пояс",
+	"Language":                     "Язык",
+	"Milestone reminders":          "Напоминания об этапах",
+	"Rating requests":              "Просьбы об оценке",
+	"Done":                         "Готово",
+	"Books read with the club: %d": "Прочитано книг с клубом: %d",
+	"Completion rate: %d%% (%d of %d started)":  "Дочитано: %d%% (%d из %d начатых)",
+	"Average pace: %.1f%% of a book per day":    "Средний темп: %.1f%% книги в день",
+	"Favourite format: ":                        "Любимый формат: ",
+	"Ratings given: %d (average %.1f/5)":        "Поставлено оценок: %d (в среднем %.1f/5)",
+	"Current streak: %s (best %d)":              "Текущая серия: %s (рекорд %d)",
+	"(club)":                                    "(клуба)",
+	"not set":                                   "не выбран",
+	"Preferences:":                              "Настройки:",
+	"Timezone: ":                                "Часовой пояс: ",
+	"Language: ":                                "Язык: ",
+	"Choose a setting to change it.":            "Выберите настройку, чтобы изменить её.",
+	"on":                                        "вкл.",
+	"off":                                       "выкл.",
+	"Enter your new display name:":              "Введите новое имя:",
+	"Choose your language:":                     "Выберите язык:",
+	"Your profile is saved.":                    "Профиль сохранён.",
+	"Please enter a name.":                      "Введите имя.",
+	"Your display name is now %s.":              "Теперь ваше имя — %s.",
+	"Please choose a language on the keyboard.": "Выберите язык на клавиатуре.",
+	"Language saved.":                           "Язык сохранён.",
+	"Enter the name of the book to add to the queue:":                                                    "Введите название книги, которую нужно добавить в очередь:",
+	"Please enter the name of the book.":                                                                 "Введите название книги.",
+	"Enter the author of the book:":                                                                      "Введите автора книги:",
+	"When do we plan to start it? E.g. 01.12.2026 or in 4 weeks:":                                        "Когда планируем начать? Например, 01.12.2026 или через 4 недели:",
+	"When is the meeting planned? E.g. 20.12.2026 or in 6 weeks:":                                        "Когда планируется встреча? Например, 20.12.2026 или через 6 недель:",
+	"\"%s\" is number %d in the queue. See /upcoming.":                                                   "«%s» — номер %d в очереди. Смотрите /upcoming.",
+	"Sorry, I couldn't understand the date. Please enter it like 25.12.2026, 25 December or in 3 weeks:": "Извините, я не понял дату. Введите её, например, так: 25.12.2026, 25 декабря или через 3 недели:",
+	"There is nothing to reorder. Add books with /queueBook.":                                            "Переставлять нечего. Добавьте книги через /queueBook.",
+	"Enter the book ID and its new position, e.g. %s 1":                                                  "Введите ID книги и её новое место, например %s 1",
+	"Please enter the book ID and the new position separated by a space.":                                "Введите ID книги и новое место через пробел.",
+	"The position must be a number greater than 0.":                                                      "Место должно быть числом больше 0.",
+	"There is no such book in the queue. Please enter the ID from the list.":                             "Такой книги в очереди нет. Введите ID из списка.",
+	"The queue is empty.":                                "Очередь пуста.",
+	"Enter the ID of the book to remove from the queue:": "Введите ID книги, которую нужно убрать из очереди:",
+	"\"%s\" was removed from the queue.":                 "«%s» убрана из очереди.",
+	"No upcoming books are planned yet.":                 "Следующие книги пока не запланированы.",
+	"Upcoming books:":                                    "Следующие книги:",
+	"start: ":                                            "начало: ",
+	"meeting: ":                                          "встреча: ",
+	"Enter manually":                                     "Ввести вручную",
+	"Enter the date, e.g. 25.12.2026, 25 December, next Friday 19:00 or in 3 weeks:": "Введите дату, например 25.12.2026, 25 декабря, в пятницу 19:00 или через 3 недели:",
+	"Enter the name of the book, its ISBN or a search query:":                        "Введите название книги, её ISBN или поисковый запрос:",
+	"Select the book:": "Выберите книгу:",
+	"Added \"%s\" by %s. Use /setBookInfo to fill in anything missing.":   "Добавлена книга «%s», автор %s. Недостающее можно заполнить через /setBookInfo.",
+	"Enter date of club's meeting. ":                                      "Введите дату встречи клуба. ",
+	"Sorry, I couldn't understand the date. ":                             "Извините, я не понял дату. ",
+	"The date must be later than today. Please enter a valid later date:": "Дата должна быть позже сегодняшней. Введите более позднюю дату:",
+	"The meeting will be on %s. Is that right?":                           "Встреча будет %s. Всё верно?",
+	"Yes":                                 "Да",
+	"No":                                  "Нет",
+	"What do you want to set for \"%s\"?": "Что вы хотите указать для «%s»?",
+	"Pages":                               "Страницы",
+	"Audiobook duration":                  "Длительность аудиокниги",
+	"Genre":                               "Жанр",
+	"Year":                                "Год",
+	"Chapters":                            "Главы",
+	"Cover":                               "Обложка",
+	"Description":                         "Описание",
+	"Please select one of the fields on the keyboard.":                                                   "Выберите одно из полей на клавиатуре.",
+	"Thank you! Use /setBookInfo to set another field.":                                                  "Спасибо! Другое поле можно заполнить через /setBookInfo.",
+	"Enter the ISBN (10 or 13 digits):":                                                                  "Введите ISBN (10 или 13 цифр):",
+	"Enter the page count. For several editions send one per line, e.g.\nHardcover: 320\nPaperback: 352": "Введите количество страниц. Для нескольких изданий — по одному в строке, например:\nТвёрдая обложка: 320\nМягкая обложка: 352",
+	"Enter the audiobook duration (hh:mm):":                                                              "Введите длительность аудиокниги (чч:мм):",
+	"Enter the genre:":                                                                                   "Введите жанр:",
+	"Enter the cover image URL:":                                                                         "Введите ссылку на изображение обложки:",
+	"Enter a short description:":                                                                         "Введите краткое описание:",
+	"Enter the publication year:":                                                                        "Введите год издания:",
+	"Enter one chapter per line with the page it starts on in the first edition, e.g.\nChapter 1: 1\nChapter 2: 24":           "Введите по одной главе в строке со страницей, с которой она начинается в первом издании, например:\nГлава 1: 1\nГлава 2: 24",
+	"Please enter a valid ISBN (10 or 13 digits).":                                                                            "Введите корректный ISBN (10 или 13 цифр).",
+	"Please enter the page count as a number, or one \"Edition: pages\" per line.":                                            "Введите количество страниц числом или по одной строке «Издание: страницы».",
+	"Please enter a link starting with http:// or https://.":                                                                  "Введите ссылку, начинающуюся с http:// или https://.",
+	"Please enter a valid year.":                                                                                              "Введите корректный год.",
+	"Please enter one \"Chapter name: start page\" per line, with the pages in increasing order.":                             "Введите по одной строке «Название главы: начальная страница», страницы — по возрастанию.",
+	"Please start again with /setBookInfo.":                                                                                   "Начните заново с /setBookInfo.",
+	"Enter the name of the meeting, e.g. Halfway discussion:":                                                                 "Введите название встречи, например «Обсуждение середины книги»:",
+	"Please enter the name of the meeting.":                                                                                   "Введите название встречи.",
+	"Enter the date of the meeting. ":                                                                                         "Введите дату встречи. ",
+	"Enter the start time (hh:mm):":                                                                                           "Введите время начала (чч:мм):",
+	"Please enter the time in format hh:mm, e.g. 19:30.":                                                                      "Введите время в формате чч:мм, например 19:30.",
+	"Enter the timezone of the meeting, e.g. Europe/Moscow:":                                                                  "Введите часовой пояс встречи, например Europe/Moscow:",
+	"Unknown timezone. Please enter a name like Europe/Moscow or Asia/Tbilisi.":                                               "Неизвестный часовой пояс. Введите название вроде Europe/Moscow или Asia/Tbilisi.",
+	"Enter the address or the video call link:":                                                                               "Введите адрес или ссылку на видеозвонок:",
+	"Enter the agenda of the meeting:":                                                                                        "Введите повестку встречи:",
+	"Thank you! Members can answer with /meetings.":                                                                           "Спасибо! Участники могут ответить через /meetings.",
+	"How far should members read? Enter a percent, e.g. 40%. Set the chapters with /setBookInfo to choose a chapter instead.": "До какого места нужно дочитать? Введите процент, например 40%. Чтобы выбирать главу, задайте главы через /setBookInfo.",
+	"Up to which chapter should members read? You can also enter a percent, e.g. 40%.":                                        "До какой главы нужно дочитать? Можно также ввести процент, например 40%.",
+	"Please select a chapter or enter a percent from 1 to 100.":                                                               "Выберите главу или введите процент от 1 до 100.",
+	"By when? Enter the date, e.g. 25.12.2026, next Friday or in 1 week:":                                                     "К какому сроку? Введите дату, например 25.12.2026, в пятницу или через 1 неделю:",
+	"Sorry, I couldn't understand the date. Please enter it like 25.12.2026 or next Friday:":                                  "Извините, я не понял дату. Введите её, например, так: 25.12.2026 или в пятницу:",
+	"Milestone added: %s by %s. Members will be reminded the day before.":                                                     "Этап добавлен: %s к %s. Участникам напомнят за день.",
+	"The club timezone is %s. Enter the new timezone, e.g. Europe/Moscow:":                                                    "Часовой пояс клуба — %s. Введите новый, например Europe/Moscow:",
+	"The club timezone is now %s.":                                                                                            "Теперь часовой пояс клуба — %s.",
+	"Your time is %s. Enter your timezone, e.g. Europe/Berlin:":                                                               "У вас сейчас %s. Введите свой часовой пояс, например Europe/Berlin:",
+	"Use club timezone":                      "Часовой пояс клуба",
+	"You are using the club timezone %s.":    "Вы используете часовой пояс клуба %s.",
+	"Your timezone is now %s.":               "Теперь ваш часовой пояс — %s.",
+	"Enter the ID or the title of the book:": "Введите ID или название книги:",
+	"No book found. Please enter the ID or the title of the book (see /getBookList):": "Книга не найдена. Введите ID или название книги (см. /getBookList):",
+	"\"%s\" is already the current book.":                                             "«%s» уже текущая книга.",
+	"\"%s\" is now the current book. Use /updateMeetingDate to set the meeting date.": "«%s» теперь текущая книга. Дату встречи можно задать через /updateMeetingDate.",
+	"Several books match. Which one?":                                                 "Подходит несколько книг. Какую выбрать?",
+	"First to finish":                                                                 "Первый, кто дочитал",
+	"Finished before the meeting":                                                     "Дочитано до встречи",
+	"First book":                                                                      "Первая книга",
+	"Never missed a meeting":                                                          "Ни одной пропущенной встречи",
+	"There are no members yet.":                                                       "Участников пока нет.",
+	"Leaderboard:":                                                                    "Рейтинг:",
+	"(best %d)":                                                                       "(рекорд %d)",
+	"Badges: ":                                                                        "Значки: ",
+	"Reminder: the club agreed to read \"%s\" up to %s by %s. You are at %d%%. Update your progress with /setProgress.": "Напоминание: клуб договорился дочитать «%s» до %s к %s. Вы сейчас на %d%%. Обновите прогресс через /setProgress.",
+	"The club language is %s. It is used for members who haven't chosen their own. Choose the new language:":            "Язык клуба — %s. Он используется для участников, которые не выбрали свой. Выберите новый язык:",
+	"The club language is now %s.":   "Теперь язык клуба — %s.",
+	"Enter full user name:":          "Введите полное имя участника:",
+	"Enter user telegram nick name:": "Введите ник участника в Telegram:",
+	"Please enter a valid nickname.": "Введите корректный ник.",
+	"User removed successfully!":     "Участник удалён!",
+	"You are not a member of the club. Please contact @alexeygav to join the club.":   "Вы не состоите в клубе. Чтобы вступить, напишите @alexeygav.",
+	"You are not a member of the club.":                                               "Вы не состоите в клубе.",
+	"Sorry, the export failed. Please try again later.":                               "Извините, экспорт не удался. Попробуйте позже.",
+	"Members, books, meetings, progress history, ratings and quotes as CSV and JSON.": "Участники, книги, встречи, история прогресса, оценки и цитаты в CSV и JSON.",
+	"Import": "Импортировать",
+	"Cancel": "Отмена",
+	"Send me a CSV file:\n- members: nickname, full name, role (admin or member)\n- past books: title, author, meeting date (dd.mm.yyyy), isbn, pages, year\n- your Goodreads library export (My Books → Import and export)\nYou will see what will be created before anything is saved.": "Пришлите CSV-файл:\n- участники: nickname, full name, role (admin или member)\n- прошлые книги: title, author, meeting date (дд.мм.гггг), isbn, pages, year\n- экспорт вашей библиотеки Goodreads (My Books → Import and export)\nПеред сохранением я покажу, что будет создано.",
+	"@%s is not a member of the club.":                               "@%s не состоит в клубе.",
+	"Dry run - nothing is saved yet.":                                "Пробный запуск - пока ничего не сохранено.",
+	"Only admins can import members and books.":                      "Импортировать участников и книги могут только администраторы.",
+	"Only admins can import to another member's shelf.":              "Импортировать на полку другого участника могут только администраторы.",
+	"Please send the CSV as a file, or /cancel.":                     "Пришлите CSV файлом или отправьте /cancel.",
+	"Sorry, I couldn't download the file. Please try /import again.": "Не удалось скачать файл. Попробуйте /import ещё раз.",
+	"Sorry, I don't recognise this file. ":                           "Не получается распознать этот файл. ",
+	"The import is cancelled.":                                       "Импорт отменён.",
+	"There is nothing to import.":                                    "Импортировать нечего.",
+	"admin":                                                          "администратор",
+	"line %d: \"%s\" is already in the book list":                    "строка %d: «%s» уже есть в списке книг",
+	"line %d: \"%s\" is already on the shelf":                        "строка %d: «%s» уже на полке",
+	"line %d: \"%s\" is not a valid nickname":                        "строка %d: «%s» - некорректный ник",
+	"line %d: \"%s\" is on the shelf \"%s\"":                         "строка %d: «%s» на полке «%s»",
+	"line %d: @%s is already a member":                               "строка %d: @%s уже участник клуба",
+	"line %d: @%s is listed twice":                                   "строка %d: @%s указан дважды",
+	"line %d: the meeting date must look like 25.12.2024":            "строка %d: дата встречи должна быть в формате 25.12.2024",
+	"line %d: the title is missing":                                  "строка %d: не указано название",
+	"line %d: unknown role \"%s\", use admin or member":              "строка %d: неизвестная роль «%s», укажите admin или member",
+	"Filter the audit log with any of:\n@alice or user:alice - actions by or about @alice\naction:RemoveUser - one of %s\nfrom:01.09.2024 and to:30.09.2024 - a date range\ne.g. /audit @alice action:AddBook from:01.09.2024": "Фильтры журнала действий:\n@alice или user:alice - действия @alice или над @alice\naction:RemoveUser - одно из %s\nfrom:01.09.2024 и to:30.09.2024 - период\nнапример, /audit @alice action:AddBook from:01.09.2024",
+	"Admin actions, newest first:":                                    "Действия администраторов, сначала новые:",
+	"No admin actions found.":                                         "Действий администраторов не найдено.",
+	"Showing %d of %d. Narrow it down with filters, see /audit help.": "Показано %d из %d. Уточните запрос фильтрами, см. /audit help.",
+	"added @%s":             "добавил(а) @%s",
+	"added @%s (%s)":        "добавил(а) @%s (%s)",
+	"added the book \"%s\"": "добавил(а) книгу «%s»",
+	"added the book \"%s\", replacing \"%s\"":     "добавил(а) книгу «%s» вместо «%s»",
+	"changed the meeting date of \"%s\": %s → %s": "изменил(а) дату встречи по «%s»: %s → %s",
+	"removed @%s":      "удалил(а) @%s",
+	"removed @%s (%s)": "удалил(а) @%s (%s)",
+	"tried to remove @%s, who was not a member":                     "пытался(ась) удалить @%s, но такого участника нет",
+	"\"%s\" has been removed.":                                      "книга «%s» удалена.",
+	"\"%s\" is no longer the current book.":                         "«%s» больше не текущая книга.",
+	"@%s is a member again.":                                        "@%s снова в клубе.",
+	"@%s is no longer a member.":                                    "@%s уже не в клубе.",
+	"@%s was not a member.":                                         "@%s не был(а) участником клуба.",
+	"Nothing was undone.":                                           "Ничего не отменено.",
+	"The grace period is over, the action can no longer be undone.": "Время на отмену истекло, действие больше нельзя отменить.",
+	"There is nothing to undo. Only your own actions from the last %d minutes can be undone.": "Отменять нечего. Можно отменить только свои действия за последние %d минут.",
+	"Undo your last action: %s?":                    "Отменить последнее действие: %s?",
+	"Undone: %s.":                                   "Отменено: %s.",
+	"Your last action can't be undone: ":            "Последнее действие нельзя отменить: ",
+	"the meeting date of \"%s\" has changed since.": "дата встречи по «%s» с тех пор изменилась.",
+	"undid: %s": "отменил(а): %s",
+	"Sorry, the book lookup failed. Please enter the title of the book:":                                  "Не удалось найти книгу в каталоге. Введите название книги:",
+	"Please choose a book on the keyboard, or \"%s\" to type it in yourself.":                             "Выберите книгу на клавиатуре или «%s», чтобы ввести её вручную.",
+	"Nothing was found for ISBN %s. Please enter the title of the book:":                                  "По ISBN %s ничего не найдено. Введите название книги:",
+	"Please enter the title of the book:":                                                                 "Введите название книги:",
+	"members have already recorded progress, ratings, notes, quotes or RSVPs for \"%s\".":                 "участники уже отметили прогресс, оценки, заметки, цитаты или ответы на встречи для «%s».",
+	"@%s is already a member of the club. Enter another nickname:":                                        "@%s уже состоит в клубе. Введите другой ник:",
+	"@%s is already a member of the club.":                                                                "@%s уже состоит в клубе.",
+	"Your progress for the current book was not found. Please start again with /setProgress.":             "Ваш прогресс по текущей книге не найден. Пожалуйста, начните заново с /setProgress.",
+	"Sorry, I couldn't send the preview. Please try /import again.":                                       "Не удалось отправить предпросмотр. Пожалуйста, попробуйте /import ещё раз.",
+	"The keyboard shows %d of %d books, type the title of any other.":                                     "На клавиатуре %d из %d книг, название любой другой можно ввести вручную.",
+	"Showing the last %d of %d books. Use /history <book ID> for any other, the IDs are in /getBookList.": "Показаны последние %d из %d книг. Для остальных используйте /history <ID книги>, ID есть в /getBookList.",
+	"See them with /quotes %s.":                                                                           "Смотрите их в /quotes %s.",
+}
+
+// russianPlurals holds the one, few and many forms.
+var russianPlurals = map[string][]string{
+	"%d day":    {"%d день", "%d дня", "%d дней"},
+	"%d page":   {"%d страница", "%d страницы", "%d страниц"},
+	"in %d day": {"через %d день", "через %d дня", "через %d дней"},
+	"%d rating": {"%d оценка", "%d оценки", "%d оценок"},
+	"You need to read %d page per day to finish the book by the meeting date %s.":    {"Чтобы дочитать книгу к встрече %[2]s, нужно читать по %[1]d странице в день.", "Чтобы дочитать книгу к встрече %[2]s, нужно читать по %[1]d страницы в день.", "Чтобы дочитать книгу к встрече %[2]s, нужно читать по %[1]d страниц в день."},
+	"Your edition currently has %d page. Enter the correct total pages of the book:": {"Сейчас в вашем издании %d страница. Введите правильное количество страниц:", "Сейчас в вашем издании %d страницы. Введите правильное количество страниц:", "Сейчас в вашем издании %d страниц. Введите правильное количество страниц:"},
+	"%d book":          {"%d книга", "%d книги", "%d книг"},
+	"%d book finished": {"дочитана %d книга", "дочитано %d книги", "дочитано %d книг"},
+	"streak %d day":    {"серия %d день", "серия %d дня", "серия %d дней"},
+	"%d book will be added to the shelf of @%s:": {"На полку @%[2]s будет добавлена %[1]d книга:", "На полку @%[2]s будут добавлены %[1]d книги:", "На полку @%[2]s будет добавлено %[1]d книг:"},
+	"%d member will be added:":                   {"Будет добавлен %d участник:", "Будут добавлены %d участника:", "Будет добавлено %d участников:"},
+	"%d past book will be added:":                {"Будет добавлена %d прошлая книга:", "Будут добавлены %d прошлые книги:", "Будет добавлено %d прошлых книг:"},
+	"%d row will be skipped:":                    {"Будет пропущена %d строка:", "Будут пропущены %d строки:", "Будет пропущено %d строк:"},
+	"Done! %d record was created.":               {"Готово! Создана %d запись.", "Готово! Созданы %d записи.", "Готово! Создано %d записей."},
+	"…and %d more":                               {"…и ещё %d", "…и ещё %d", "…и ещё %d"},
+	"%d earlier quote is not shown.":             {"%d более ранняя цитата не показана.", "%d более ранние цитаты не показаны.", "%d более ранних цитат не показано."},
+}
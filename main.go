@@ -5,17 +5,33 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"telegram-bot/backup"
+	"telegram-bot/calendar"
 	"telegram-bot/commandhandler"
 	"telegram-bot/database"
+	"telegram-bot/export"
+	"telegram-bot/i18n"
+	"telegram-bot/scheduler"
+	// The runtime image has no zoneinfo; embed it so club and member timezones load.
+	_ "time/tzdata"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func main() {
+	if backup.IsCommand(os.Args[1:]) {
+		if err := backup.Run(os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	go func() {
 		http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 			fmt.Fprintln(w, "Telegram bot is running!")
 		})
+		http.HandleFunc("GET /clubs/{club}/calendar.ics", calendar.FeedHandler)
+		http.HandleFunc("GET /clubs/{club}/export.zip", export.Handler)
 
 		port := os.Getenv("PORT")
 		if port == "" {
@@ -37,6 +53,8 @@ func main() {
 
 	bot.Debug = true
 
+	scheduler.Start(bot)
+
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
@@ -48,12 +66,38 @@ func main() {
 			username := update.Message.From.UserName
 			isUserBelongsToClub := database.IsUserBelongsToClub(username)
 			if !isUserBelongsToClub {
-				msg := tgbotapi.NewMessage(update.Message.Chat.ID, "You are not a member of the club. Please contact @alexeygav to join the club.")
+				msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(telegramLanguage(update.Message.From), "You are not a member of the club. Please contact @alexeygav to join the club."))
 				bot.Send(msg)
 				continue
 			}
 
+			details := database.GetUserDetails(username)
+			if update.Message.Chat.IsPrivate() && details.ChatID != update.Message.Chat.ID {
+				database.SetUserChatID(username, update.Message.Chat.ID)
+			}
+			// Keep the Telegram language as the last fallback of User.Lang. Language
+			// itself is only set by the member, with /language or /me.
+			if language := i18n.FromLanguageCode(update.Message.From.LanguageCode); language != "" && language != details.TelegramLanguage {
+				database.SetUserTelegramLanguage(username, language)
+			}
+
 			commandhandler.HandleCommand(bot, update, username)
 		}
+		if update.CallbackQuery != nil {
+			if !database.IsUserBelongsToClub(update.CallbackQuery.From.UserName) {
+				bot.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, i18n.T(telegramLanguage(update.CallbackQuery.From), "You are not a member of the club.")))
+				continue
+			}
+
+			commandhandler.HandleCallback(bot, update)
+		}
+	}
+}
+
+// telegramLanguage is the language for people who are not members yet.
+func telegramLanguage(user *tgbotapi.User) string {
+	if language := i18n.FromLanguageCode(user.LanguageCode); language != "" {
+		return language
 	}
+	return database.ClubLanguage()
 }
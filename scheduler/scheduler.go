@@ -0,0 +1,135 @@
+// Package scheduler runs the bot's periodic jobs.
+package scheduler
+
+import (
+	"log"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/ratebook"
+	"telegram-bot/utils"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const interval = time.Hour
+
+// ratingWindow is how long after the final meeting members are still asked for a rating.
+const ratingWindow = 7
+
+// Start runs the jobs in the background every hour.
+func Start(bot *tgbotapi.BotAPI) {
+	go func() {
+		for {
+			run(bot)
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func run(bot *tgbotapi.BotAPI) {
+	// Ratings go first so the book that is about to be replaced is still current.
+	askForRatings(bot)
+	remindMilestones(bot)
+	activateNextBook()
+}
+
+// remindMilestones tells members who haven't reached a milestone yet about it
+// the day before its deadline.
+func remindMilestones(bot *tgbotapi.BotAPI) {
+	book := database.GetCurrentBook()
+	if book.BookID == "" {
+		return
+	}
+	location := database.ClubLocation()
+	today := time.Now().In(location)
+	changed := false
+	for i, milestone := range book.Milestones {
+		deadline, err := utils.ParseDate(milestone.Deadline, location)
+		if milestone.Reminded || err != nil || utils.DaysBetween(today, deadline) > 1 {
+			continue
+		}
+		progresses := map[string]int{}
+		for _, progress := range database.BookProgress(book.BookID) {
+			progresses[progress.UserName] = progress.Progress
+		}
+		for _, user := range database.UserList() {
+			if user.ChatID == 0 || user.NoMilestoneReminders || progresses[user.UserName] >= milestone.Percent {
+				continue
+			}
+			text := i18n.T(user.Lang(), "Reminder: the club agreed to read \"%s\" up to %s by %s. You are at %d%%. Update your progress with /setProgress.",
+				book.Title, milestone.Title, milestone.Deadline, progresses[user.UserName])
+			bot.Send(tgbotapi.NewMessage(user.ChatID, text))
+		}
+		book.Milestones[i].Reminded = true
+		changed = true
+	}
+	if changed {
+		database.UpdateBookField(book.BookID, "Milestones", book.Milestones)
+	}
+}
+
+// askForRatings asks every member who hasn't rated a book yet for a rating once,
+// after the book's final meeting. Only members who have a private chat with the bot
+// and are not in the middle of another dialog are asked.
+func askForRatings(bot *tgbotapi.BotAPI) {
+	location := database.ClubLocation()
+	today := time.Now().In(location)
+	for _, book := range database.BookList() {
+		if book.RatingsRequested || book.Queued || book.MeetingDate == "" {
+			continue
+		}
+		meetingDate, err := utils.ParseDate(book.MeetingDate, location)
+		if err != nil {
+			continue
+		}
+		if days := utils.DaysBetween(meetingDate, today); days < 1 || days > ratingWindow {
+			continue
+		}
+		for _, user := range database.UserList() {
+			if user.ChatID == 0 || user.NoRatingRequests || user.Status != "" || database.HasRated(book.BookID, user.UserName) {
+				continue
+			}
+			ratebook.AskForRating(user.UserName, book, bot, user.ChatID)
+		}
+		database.UpdateBookField(book.BookID, "RatingsRequested", true)
+		log.Printf("Asked members to rate '%s'", book.Title)
+	}
+}
+
+// activateNextBook moves to the first queued book once the current book's meeting
+// has passed and the queued book's planned start (if any) has come.
+func activateNextBook() {
+	queue := database.QueuedBooks()
+	if len(queue) == 0 {
+		return
+	}
+	location := database.ClubLocation()
+	today := time.Now().In(location)
+
+	current := database.GetCurrentBook()
+	if current.BookID != "" {
+		if current.MeetingDate == "" {
+			return
+		}
+		meetingDate, err := utils.ParseDate(current.MeetingDate, location)
+		if err != nil || utils.DaysBetween(meetingDate, today) < 1 {
+			return
+		}
+	}
+
+	next := queue[0]
+	if next.PlannedStart != "" {
+		plannedStart, err := utils.ParseDate(next.PlannedStart, location)
+		if err == nil && utils.DaysBetween(today, plannedStart) > 0 {
+			return
+		}
+	}
+
+	database.SetActiveBook(next.BookID)
+	if next.PlannedMeeting != "" {
+		database.UpdateBookDate(next.BookID, next.PlannedMeeting)
+		database.SetFinalMeetingDate(next.BookID, next.PlannedMeeting)
+	}
+	log.Printf("Activated the next queued book '%s'", next.Title)
+}
@@ -0,0 +1,200 @@
+package addmeeting
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/dateparse"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const skip = "Skip"
+
+const datePrompt = "Enter the date, e.g. 25.12.2026, 25 December, next Friday 19:00 or in 3 weeks:"
+
+// draft is the meeting being added, kept in the user's draft until the last step,
+// so an abandoned dialog leaves nothing behind.
+type draft struct {
+	Meeting database.Meeting `json:"meeting"`
+	// Date and Time wait here until the admin confirms them.
+	Date string `json:"date"`
+	Time string `json:"time"`
+}
+
+func loadDraft(user string) draft {
+	var d draft
+	if err := json.Unmarshal([]byte(database.UserDraft(user)), &d); err != nil {
+		log.Fatalf("Failed to unmarshal meeting draft: %s", err)
+	}
+	return d
+}
+
+func saveDraft(user string, d draft) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		log.Fatalf("Failed to marshal meeting draft: %s", err)
+	}
+	database.SetUserDraft(user, string(data))
+}
+
+func AddMeetingDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	currentBook := database.GetCurrentBook()
+	if currentBook.BookID == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No active book found.")))
+		return
+	}
+	database.SetUserStatus(user, "enter_meeting_title")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the name of the meeting, e.g. Halfway discussion:"))
+	msg.ReplyMarkup = keyboard(i18n.T(lang, "Halfway discussion"), i18n.T(lang, "Final meeting"))
+	bot.Send(msg)
+}
+
+func EnterMeetingTitle(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	title := strings.TrimSpace(update.Message.Text)
+	if title == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter the name of the meeting.")))
+		return
+	}
+	currentBook := database.GetCurrentBook()
+	saveDraft(user, draft{Meeting: database.Meeting{BookID: currentBook.BookID, Title: title}})
+	database.SetUserStatus(user, "enter_meeting_date")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the date of the meeting. ")+i18n.T(lang, datePrompt)))
+}
+
+func EnterMeetingDate(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	now := time.Now().In(database.ClubLocation())
+	parsed, err := dateparse.Parse(update.Message.Text, now)
+	if err != nil {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, I couldn't understand the date. ")+i18n.T(lang, datePrompt)))
+		return
+	}
+	if utils.DaysBetween(now, parsed.Date) < 1 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The date must be later than today. Please enter a valid later date:")))
+		return
+	}
+	d := loadDraft(user)
+	d.Date = parsed.Date.Format("02.01.2006")
+	d.Time = ""
+	if parsed.HasTime {
+		d.Time = parsed.Date.Format("15:04")
+	}
+	saveDraft(user, d)
+	database.SetUserStatus(user, "confirm_meeting_date")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The meeting will be on %s. Is that right?", dateparse.Format(parsed, lang)))
+	msg.ReplyMarkup = keyboard(i18n.T(lang, "Yes"), i18n.T(lang, "No"))
+	bot.Send(msg)
+}
+
+func ConfirmMeetingDate(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	answer := strings.TrimSpace(update.Message.Text)
+	switch {
+	case strings.EqualFold(answer, i18n.T(lang, "Yes")):
+		d := loadDraft(user)
+		d.Meeting.Date, d.Meeting.Time = d.Date, d.Time
+		saveDraft(user, d)
+		if d.Meeting.Time != "" {
+			askTimezone(user, bot, update)
+			return
+		}
+		database.SetUserStatus(user, "enter_meeting_time")
+		msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the start time (hh:mm):"))
+		msg.ReplyMarkup = keyboard(i18n.T(lang, skip))
+		bot.Send(msg)
+	case strings.EqualFold(answer, i18n.T(lang, "No")):
+		database.SetUserStatus(user, "enter_meeting_date")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, datePrompt)))
+	default:
+		database.SetUserStatus(user, "enter_meeting_date")
+		EnterMeetingDate(user, bot, update)
+	}
+}
+
+func EnterMeetingTime(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	if text != i18n.T(lang, skip) {
+		if _, err := time.Parse("15:04", text); err != nil {
+			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter the time in format hh:mm, e.g. 19:30.")))
+			return
+		}
+		d := loadDraft(user)
+		d.Meeting.Time = text
+		saveDraft(user, d)
+	}
+	askTimezone(user, bot, update)
+}
+
+func askTimezone(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserStatus(user, "enter_meeting_timezone")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the timezone of the meeting, e.g. Europe/Moscow:"))
+	msg.ReplyMarkup = keyboard(database.ClubTimezone())
+	bot.Send(msg)
+}
+
+func EnterMeetingTimezone(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	if !utils.IsValidTimezone(text) {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Unknown timezone. Please enter a name like Europe/Moscow or Asia/Tbilisi.")))
+		return
+	}
+	d := loadDraft(user)
+	d.Meeting.Timezone = text
+	saveDraft(user, d)
+	database.SetUserStatus(user, "enter_meeting_place")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the address or the video call link:"))
+	msg.ReplyMarkup = keyboard(i18n.T(lang, skip))
+	bot.Send(msg)
+}
+
+func EnterMeetingPlace(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	if text != i18n.T(lang, skip) {
+		d := loadDraft(user)
+		if strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://") {
+			d.Meeting.Link = text
+		} else {
+			d.Meeting.Location = text
+		}
+		saveDraft(user, d)
+	}
+	database.SetUserStatus(user, "enter_meeting_agenda")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the agenda of the meeting:"))
+	msg.ReplyMarkup = keyboard(i18n.T(lang, skip))
+	bot.Send(msg)
+}
+
+// EnterMeetingAgenda is the last step; only here is the meeting saved.
+func EnterMeetingAgenda(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	d := loadDraft(user)
+	if text != i18n.T(lang, skip) {
+		d.Meeting.Agenda = text
+	}
+	database.AddMeeting(d.Meeting)
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Thank you! Members can answer with /meetings.")))
+}
+
+func keyboard(labels ...string) tgbotapi.ReplyKeyboardMarkup {
+	var buttons []tgbotapi.KeyboardButton
+	for _, label := range labels {
+		buttons = append(buttons, tgbotapi.NewKeyboardButton(label))
+	}
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(buttons...))
+	keyboard.OneTimeKeyboard = true
+	return keyboard
+}
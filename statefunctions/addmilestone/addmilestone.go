@@ -0,0 +1,80 @@
+package addmilestone
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/dateparse"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/setprogress"
+	"telegram-bot/utils"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var percentPattern = regexp.MustCompile(`^(\d{1,3})\s*%$`)
+
+func AddMilestoneDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	currentBook := database.GetCurrentBook()
+	if currentBook.BookID == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No active book found.")))
+		return
+	}
+	database.SetUserStatus(user, "enter_milestone_target")
+	if len(currentBook.Chapters) == 0 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "How far should members read? Enter a percent, e.g. 40%. Set the chapters with /setBookInfo to choose a chapter instead.")))
+		return
+	}
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Up to which chapter should members read? You can also enter a percent, e.g. 40%."))
+	msg.ReplyMarkup = setprogress.ChapterKeyboard(currentBook)
+	bot.Send(msg)
+}
+
+func EnterMilestoneTarget(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	currentBook := database.GetCurrentBook()
+	var title string
+	var percent int
+	if i, ok := currentBook.FindChapter(text); ok {
+		title, percent = currentBook.Chapters[i].Name, currentBook.ChapterEnd(i)
+	} else if m := percentPattern.FindStringSubmatch(text); m != nil {
+		percent, _ = strconv.Atoi(m[1])
+		title = m[1] + "%"
+	}
+	if percent < 1 || percent > 100 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please select a chapter or enter a percent from 1 to 100.")))
+		return
+	}
+	database.SetUserDraft(user, strconv.Itoa(percent)+"|"+title)
+	database.SetUserStatus(user, "enter_milestone_deadline")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "By when? Enter the date, e.g. 25.12.2026, next Friday or in 1 week:")))
+}
+
+func EnterMilestoneDeadline(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	now := time.Now().In(database.ClubLocation())
+	parsed, err := dateparse.Parse(update.Message.Text, now)
+	if err != nil {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, I couldn't understand the date. Please enter it like 25.12.2026 or next Friday:")))
+		return
+	}
+	if utils.DaysBetween(now, parsed.Date) < 1 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The date must be later than today. Please enter a valid later date:")))
+		return
+	}
+	percentText, title, _ := strings.Cut(database.UserDraft(user), "|")
+	percent, _ := strconv.Atoi(percentText)
+	milestone := database.Milestone{Title: title, Percent: percent, Deadline: parsed.Date.Format("02.01.2006")}
+
+	currentBook := database.GetCurrentBook()
+	milestones := append(currentBook.Milestones, milestone)
+	database.SortMilestones(milestones)
+	database.UpdateBookField(currentBook.BookID, "Milestones", milestones)
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Milestone added: %s by %s. Members will be reminded the day before.", title, dateparse.Format(parsed, lang))))
+}
@@ -0,0 +1,153 @@
+package addnote
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const noSpoilers = "No spoilers"
+
+const positionPrompt = "Which part of the book does it refer to? Enter a page (e.g. page 120) or a percent (e.g. 45%). Members who haven't got there yet won't see it."
+
+var (
+	percentPattern = regexp.MustCompile(`^(\d{1,3})\s*%$`)
+	pagePattern    = regexp.MustCompile(`^(?:p\.?|page|стр\.?|страница)?\s*(\d+)$`)
+)
+
+// AddNoteDefault starts adding a discussion question (/addQuestion) or a note (/addNote).
+func AddNoteDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if database.GetCurrentBook().BookID == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No active book found.")))
+		return
+	}
+	kind := database.ReadingNote
+	if update.Message.Command() == "addQuestion" {
+		kind = database.Question
+	}
+	database.SetUserDraft(user, string(kind))
+	database.SetUserStatus(user, "enter_note_position")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, positionPrompt))
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, noSpoilers))))
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func EnterNotePosition(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	percent, label := 0, ""
+	if text != i18n.T(lang, noSpoilers) {
+		var problem string
+		percent, label, problem = parsePosition(user, lang, strings.ToLower(text))
+		if problem != "" {
+			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
+			return
+		}
+	}
+	database.SetUserDraft(user, database.UserDraft(user)+"|"+strconv.Itoa(percent)+"|"+label)
+	database.SetUserStatus(user, "enter_note_text")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the text:")))
+}
+
+func EnterNoteText(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	if text == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter some text.")))
+		return
+	}
+	parts := strings.SplitN(database.UserDraft(user), "|", 3)
+	if len(parts) != 3 {
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Something went wrong. Please start again with /addNote or /addQuestion.")))
+		return
+	}
+	percent, _ := strconv.Atoi(parts[1])
+	database.AddNote(database.Note{
+		BookID:   database.GetCurrentBook().BookID,
+		UserName: user,
+		Kind:     database.NoteKind(parts[0]),
+		Text:     text,
+		Percent:  percent,
+		Label:    parts[2],
+	})
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Thank you! Members can see it with /questions.")))
+}
+
+// parsePosition turns "45%" or "page 120" into a percent of the book. Pages are converted
+// with the member's own page count, or the first edition's if they don't read on paper.
+func parsePosition(user, lang, text string) (int, string, string) {
+	if m := percentPattern.FindStringSubmatch(text); m != nil {
+		percent, _ := strconv.Atoi(m[1])
+		if percent < 1 || percent > 100 {
+			return 0, "", i18n.T(lang, "Please enter a percent from 1 to 100.")
+		}
+		return percent, m[1] + "%", ""
+	}
+	m := pagePattern.FindStringSubmatch(text)
+	if m == nil {
+		return 0, "", i18n.T(lang, "Sorry, I didn't understand you. ") + i18n.T(lang, positionPrompt)
+	}
+	page, _ := strconv.Atoi(m[1])
+	totalPages := 0
+	if progress := database.UserProgress(user); progress != nil && progress.Type == database.RegularBook {
+		totalPages = progress.TotalPages
+	}
+	if editions := database.GetCurrentBook().Editions; totalPages == 0 && len(editions) > 0 {
+		totalPages = editions[0].Pages
+	}
+	if totalPages == 0 {
+		return 0, "", i18n.T(lang, "I don't know the page count of the book. Please enter a percent instead, e.g. 45%.")
+	}
+	if page < 1 || page > totalPages {
+		return 0, "", i18n.T(lang, "Please enter a page from 1 to %d.", totalPages)
+	}
+	return utils.Percent(page, totalPages), i18n.T(lang, "page %d", page), ""
+}
+
+// FormatNotes lists the questions and notes of the current book as HTML. Those beyond
+// the reader's progress are sent as spoilers, so they stay hidden until tapped.
+func FormatNotes(user, lang string) string {
+	book := database.GetCurrentBook()
+	if book.BookID == "" {
+		return i18n.T(lang, "No active book found.")
+	}
+	notes := database.BookNotes(book.BookID)
+	if len(notes) == 0 {
+		return i18n.T(lang, "There are no questions or notes yet. Add one with /addQuestion or /addNote.")
+	}
+	readerPercent := 0
+	if progress := database.UserProgress(user); progress != nil {
+		readerPercent = progress.Progress
+	}
+
+	result := i18n.T(lang, "Questions and notes for %s:", html.EscapeString(book.Title)) + "\n"
+	for _, note := range notes {
+		title := i18n.T(lang, "Note")
+		if note.Kind == database.Question {
+			title = i18n.T(lang, "Question")
+		}
+		if note.Label != "" {
+			title += ", " + note.Label
+		}
+		title += i18n.T(lang, ", by %s", note.UserName)
+		text := html.EscapeString(note.Text)
+		if note.Percent > readerPercent {
+			title += i18n.T(lang, " (ahead of you)")
+			text = "<tg-spoiler>" + text + "</tg-spoiler>"
+		}
+		result += "\n<b>" + html.EscapeString(title) + "</b>\n" + text + "\n"
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package addquote
+
+import (
+	"math/rand"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const skip = "Skip"
+
+// quoteBudget is how many characters of quotes one message may hold, leaving room
+// for the heading under Telegram's limit of 4096.
+const quoteBudget = 3500
+
+// AddQuoteDefault saves a quote for the current book. The passage can follow the
+// command, e.g. "/quote All happy families are alike", or be sent in the next message.
+func AddQuoteDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if database.GetCurrentBook().BookID == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No active book found.")))
+		return
+	}
+	if text := strings.TrimSpace(update.Message.CommandArguments()); text != "" {
+		askReference(user, text, bot, update)
+		return
+	}
+	database.SetUserStatus(user, "enter_quote_text")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Send me the passage you want to save:")))
+}
+
+func EnterQuoteText(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	if text == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please send the text of the quote.")))
+		return
+	}
+	askReference(user, text, bot, update)
+}
+
+func askReference(user, text string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserDraft(user, text)
+	database.SetUserStatus(user, "enter_quote_reference")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the page or chapter, e.g. p. 120 or Chapter 3:"))
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, skip))))
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func EnterQuoteReference(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	reference := strings.TrimSpace(update.Message.Text)
+	if reference == i18n.T(lang, skip) {
+		reference = ""
+	}
+	database.AddQuote(database.Quote{
+		BookID:    database.GetCurrentBook().BookID,
+		UserName:  user,
+		Text:      database.UserDraft(user),
+		Reference: reference,
+	})
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The quote is saved. See all quotes with /quotes.")))
+}
+
+// Quotes answers /quotes: all quotes of the current book, "/quotes random" for a random
+// quote from any book, or "/quotes <book ID>" for the quotes of a past book.
+func Quotes(arguments, lang string) string {
+	arguments = strings.TrimSpace(arguments)
+	if strings.EqualFold(arguments, "random") {
+		return randomQuote(lang)
+	}
+	book := database.GetCurrentBook()
+	if arguments != "" {
+		if id, ok := database.ParseBookChoice(arguments); ok {
+			arguments = id
+		}
+		matches := database.FindBook(arguments)
+		if len(matches) != 1 {
+			return i18n.T(lang, "No such book. Use /getBookList to find the book ID.")
+		}
+		book = matches[0]
+	}
+	if book.BookID == "" {
+		return i18n.T(lang, "No active book found.")
+	}
+	quotes := database.BookQuotes(book.BookID)
+	if len(quotes) == 0 {
+		return i18n.T(lang, "There are no quotes from \"%s\" yet. Save one with /quote.", book.Title)
+	}
+	return i18n.T(lang, "Quotes from \"%s\":", book.Title) + "\n\n" + LatestQuotes(quotes, lang, quoteBudget)
+}
+
+func randomQuote(lang string) string {
+	var quotes []database.Quote
+	titles := map[string]string{}
+	for _, book := range database.BookList() {
+		titles[book.BookID] = book.Title
+		quotes = append(quotes, database.BookQuotes(book.BookID)...)
+	}
+	if len(quotes) == 0 {
+		return i18n.T(lang, "There are no quotes yet. Save one with /quote.")
+	}
+	quote := quotes[rand.Intn(len(quotes))]
+	return LatestQuotes([]database.Quote{quote}, lang, quoteBudget) + "— " + titles[quote.BookID]
+}
+
+// FormatQuotes lists quotes with their page or chapter and contributor.
+func FormatQuotes(quotes []database.Quote, lang string) string {
+	result := ""
+	for _, quote := range quotes {
+		result += "«" + quote.Text + "»\n"
+		if quote.Reference != "" {
+			result += quote.Reference + ", "
+		}
+		result += i18n.T(lang, "added by %s", quote.UserName) + "\n\n"
+	}
+	return result
+}
+
+// LatestQuotes formats the most recent quotes that fit in budget characters and
+// tells how many earlier ones were left out. A single quote longer than the budget
+// is cut short.
+func LatestQuotes(quotes []database.Quote, lang string, budget int) string {
+	first, size := len(quotes), 0
+	for first > 0 {
+		quoteSize := utf8.RuneCountInString(FormatQuotes(quotes[first-1:first], lang))
+		if size+quoteSize > budget {
+			break
+		}
+		size += quoteSize
+		first--
+	}
+	if first == len(quotes) && len(quotes) > 0 {
+		last := quotes[len(quotes)-1]
+		last.Text = truncate(last.Text, budget/2)
+		last.Reference = truncate(last.Reference, budget/4)
+		first--
+		quotes = append(quotes[:first:first], last)
+	}
+	result := ""
+	if first > 0 {
+		result = i18n.N(lang, first, "%d earlier quote is not shown.", "%d earlier quotes are not shown.", first) + "\n\n"
+	}
+	return result + FormatQuotes(quotes[first:], lang)
+}
+
+func truncate(text string, limit int) string {
+	if runes := []rune(text); len(runes) > limit {
+		return string(runes[:max(limit, 0)]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,33 @@
+package addquote
+
+import (
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLatestQuotesFitInOneMessage(t *testing.T) {
+	var quotes []database.Quote
+	for i := 0; i < 200; i++ {
+		text := strings.Repeat("All happy families are alike. ", 5) + "#" + strconv.Itoa(i)
+		quotes = append(quotes, database.Quote{UserName: "alice", Text: text, Reference: "p. " + strconv.Itoa(i)})
+	}
+	result := LatestQuotes(quotes, "en", quoteBudget)
+	if length := utf8.RuneCountInString(result); length > 4096 {
+		t.Errorf("quotes are %d characters, more than a Telegram message holds", length)
+	}
+	if !strings.HasSuffix(result, "p. 199, added by alice\n\n") || !strings.Contains(result, "earlier quotes are not shown") {
+		t.Errorf("expected the latest quotes and a count of the rest:\n%s", result)
+	}
+
+	long := []database.Quote{{UserName: "alice", Text: strings.Repeat("word ", 2000)}}
+	result = LatestQuotes(long, "en", quoteBudget)
+	if length := utf8.RuneCountInString(result); length > quoteBudget {
+		t.Errorf("a single long quote is %d characters, more than the budget", length)
+	}
+	if !strings.Contains(result, "…»") {
+		t.Errorf("a long quote should be cut short:\n%s", result)
+	}
+}
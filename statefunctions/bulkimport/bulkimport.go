@@ -0,0 +1,155 @@
+// Package bulkimport creates members, past books or a member's shelf from an uploaded CSV.
+// Every import is previewed as a dry run and only saved after confirmation.
+package bulkimport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// maxFileSize keeps a mistaken upload from being read into memory.
+const maxFileSize = 5 << 20
+
+const (
+	confirm = "Import"
+	cancel  = "Cancel"
+)
+
+const filePrompt = "Send me a CSV file:\n" +
+	"- members: nickname, full name, role (admin or member)\n" +
+	"- past books: title, author, meeting date (dd.mm.yyyy), isbn, pages, year\n" +
+	"- your Goodreads library export (My Books → Import and export)\n" +
+	"You will see what will be created before anything is saved."
+
+// ImportDefault answers /import. Admins may add a nickname to import a Goodreads
+// export to that member's shelf, e.g. "/import alice".
+func ImportDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	target := user
+	if nickname := strings.TrimPrefix(strings.TrimSpace(update.Message.CommandArguments()), "@"); nickname != "" {
+		if !database.IsUserAdmin(user) {
+			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Only admins can import to another member's shelf.")))
+			return
+		}
+		if !database.IsUserExists(nickname) {
+			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "@%s is not a member of the club.", nickname)))
+			return
+		}
+		target = nickname
+	}
+	database.SetUserDraft(user, target)
+	database.SetUserStatus(user, "enter_import_file")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, filePrompt)))
+}
+
+func EnterImportFile(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	document := update.Message.Document
+	if document == nil {
+		if strings.TrimSpace(update.Message.Text) == "/cancel" {
+			done(user, i18n.T(lang, "The import is cancelled."), bot, update)
+			return
+		}
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please send the CSV as a file, or /cancel.")))
+		return
+	}
+	target := database.UserDraft(user)
+	plan, ok := load(user, target, document.FileID, bot, update)
+	if !ok {
+		return
+	}
+	if plan.Empty() {
+		done(user, plan.Preview(lang)+"\n"+i18n.T(lang, "There is nothing to import."), bot, update)
+		return
+	}
+	database.SetUserDraft(user, target+"|"+document.FileID)
+	database.SetUserStatus(user, "confirm_import")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, plan.Preview(lang))
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(
+		tgbotapi.NewKeyboardButton(i18n.T(lang, confirm)),
+		tgbotapi.NewKeyboardButton(i18n.T(lang, cancel)),
+	))
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	if _, err := bot.Send(msg); err != nil {
+		log.Printf("Error sending import preview: %s", err)
+		done(user, i18n.T(lang, "Sorry, I couldn't send the preview. Please try /import again."), bot, update)
+	}
+}
+
+func ConfirmImport(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if strings.TrimSpace(update.Message.Text) != i18n.T(lang, confirm) {
+		done(user, i18n.T(lang, "The import is cancelled."), bot, update)
+		return
+	}
+	target, fileID, _ := strings.Cut(database.UserDraft(user), "|")
+	// The file is read again, so anything added since the preview is skipped, not duplicated.
+	plan, ok := load(user, target, fileID, bot, update)
+	if !ok {
+		return
+	}
+	created := plan.Apply(user)
+	log.Printf("%s imported %d %s records", user, created, plan.Kind)
+	done(user, i18n.N(lang, created, "Done! %d record was created.", "Done! %d records were created.", created), bot, update)
+}
+
+// load downloads the file and plans the import. Members and books can only be
+// imported by admins.
+func load(user, target, fileID string, bot *tgbotapi.BotAPI, update tgbotapi.Update) (Plan, bool) {
+	lang := database.UserLanguage(user)
+	data, err := download(bot, fileID)
+	if err != nil {
+		log.Printf("Failed to download import file: %s", err)
+		done(user, i18n.T(lang, "Sorry, I couldn't download the file. Please try /import again."), bot, update)
+		return Plan{}, false
+	}
+	plan, err := Parse(bytes.NewReader(data), target, lang)
+	if err != nil {
+		log.Printf("Failed to parse import file: %s", err)
+		done(user, i18n.T(lang, "Sorry, I don't recognise this file. ")+i18n.T(lang, filePrompt), bot, update)
+		return Plan{}, false
+	}
+	if plan.Kind != Goodreads && !database.IsUserAdmin(user) {
+		done(user, i18n.T(lang, "Only admins can import members and books."), bot, update)
+		return Plan{}, false
+	}
+	return plan, true
+}
+
+func download(bot *tgbotapi.BotAPI, fileID string) ([]byte, error) {
+	url, err := bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxFileSize)
+	}
+	return data, nil
+}
+
+func done(user, text string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, text))
+}
@@ -0,0 +1,282 @@
+package bulkimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+	"time"
+)
+
+// Kind is the layout of an uploaded CSV, recognised by its header.
+type Kind string
+
+const (
+	Members   Kind = "members"   // nickname, full name, role
+	Books     Kind = "books"     // title, author, meeting date, isbn, pages, year
+	Goodreads Kind = "goodreads" // the library export from goodreads.com
+)
+
+var errUnknownFormat = errors.New("unknown CSV format")
+
+// Plan is what an import would do. It is shown to the admin as a dry run and
+// carried out only after they confirm.
+type Plan struct {
+	Kind    Kind
+	Target  string // the member whose shelf a Goodreads export is imported to
+	Members []database.User
+	Books   []database.Book
+	Shelf   []database.ShelfBook
+	Skipped []string
+}
+
+// header maps lowercased column names to their index.
+type header map[string]int
+
+func (h header) get(row []string, names ...string) string {
+	for _, name := range names {
+		if i, ok := h[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+	}
+	return ""
+}
+
+// Parse reads a CSV and works out what importing it would create or skip.
+// target is only used for Goodreads exports.
+func Parse(r io.Reader, target, lang string) (Plan, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return Plan{}, err
+	}
+	if len(rows) == 0 {
+		return Plan{}, errUnknownFormat
+	}
+	h := header{}
+	for i, name := range rows[0] {
+		h[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	rows = rows[1:]
+
+	_, hasTitle := h["title"]
+	_, hasShelf := h["exclusive shelf"]
+	_, hasNickname := h["nickname"]
+	switch {
+	case hasTitle && hasShelf:
+		return planGoodreads(h, rows, target, lang), nil
+	case hasNickname:
+		return planMembers(h, rows, lang), nil
+	case hasTitle:
+		return planBooks(h, rows, lang), nil
+	}
+	return Plan{}, errUnknownFormat
+}
+
+func planMembers(h header, rows [][]string, lang string) Plan {
+	plan := Plan{Kind: Members}
+	seen := map[string]bool{}
+	for n, row := range rows {
+		line := n + 2
+		nickname := strings.TrimPrefix(h.get(row, "nickname"), "@")
+		name := h.get(row, "full name", "fullname", "name")
+		role := strings.ToLower(h.get(row, "role"))
+		switch {
+		case nickname == "" && name == "":
+			continue
+		case !utils.IsValidTelegramNickname(nickname):
+			plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: \"%s\" is not a valid nickname", line, nickname))
+		case role != "" && role != "admin" && role != "member":
+			plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: unknown role \"%s\", use admin or member", line, role))
+		case seen[strings.ToLower(nickname)]:
+			plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: @%s is listed twice", line, nickname))
+		case database.IsUserExists(nickname):
+			plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: @%s is already a member", line, nickname))
+		default:
+			if name == "" {
+				name = nickname
+			}
+			plan.Members = append(plan.Members, database.User{UserName: nickname, FullName: name, IsAdmin: role == "admin"})
+		}
+		seen[strings.ToLower(nickname)] = true
+	}
+	return plan
+}
+
+func planBooks(h header, rows [][]string, lang string) Plan {
+	plan := Plan{Kind: Books}
+	existing := map[string]bool{}
+	for _, book := range database.BookList() {
+		existing[bookKey(book.Title, book.Author)] = true
+	}
+	location := database.ClubLocation()
+	for n, row := range rows {
+		line := n + 2
+		book := database.Book{
+			Title:       utils.NormalizeQuotes(h.get(row, "title")),
+			Author:      h.get(row, "author"),
+			MeetingDate: h.get(row, "meeting date", "date"),
+			ISBN:        strings.NewReplacer("-", "", " ", "").Replace(h.get(row, "isbn")),
+			Genre:       h.get(row, "genre"),
+		}
+		if book.Title == "" {
+			if book.Author != "" {
+				plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: the title is missing", line))
+			}
+			continue
+		}
+		if existing[bookKey(book.Title, book.Author)] {
+			plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: \"%s\" is already in the book list", line, book.Title))
+			continue
+		}
+		if book.MeetingDate != "" {
+			date, err := utils.ParseDate(book.MeetingDate, location)
+			if err != nil {
+				plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: the meeting date must look like 25.12.2024", line))
+				continue
+			}
+			book.FinishedAt = date.UTC()
+		}
+		if pages, err := strconv.Atoi(h.get(row, "pages")); err == nil && pages > 0 {
+			book.Editions = []database.Edition{{Pages: pages}}
+		}
+		if year, err := strconv.Atoi(h.get(row, "year")); err == nil && year > 0 {
+			book.Year = year
+		}
+		existing[bookKey(book.Title, book.Author)] = true
+		plan.Books = append(plan.Books, book)
+	}
+	return plan
+}
+
+// goodreadsShelves maps Goodreads' exclusive shelves to ours.
+var goodreadsShelves = map[string]database.Shelf{
+	"read":              database.Read,
+	"currently-reading": database.Reading,
+	"to-read":           database.WantToRead,
+}
+
+func planGoodreads(h header, rows [][]string, target, lang string) Plan {
+	plan := Plan{Kind: Goodreads, Target: target}
+	existing := map[string]bool{}
+	for _, book := range database.UserShelf(target) {
+		existing[bookKey(book.Title, "")] = true
+	}
+	for n, row := range rows {
+		line := n + 2
+		title := utils.NormalizeQuotes(h.get(row, "title"))
+		if title == "" {
+			continue
+		}
+		shelf, ok := goodreadsShelves[h.get(row, "exclusive shelf")]
+		if !ok {
+			plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: \"%s\" is on the shelf \"%s\"", line, title, h.get(row, "exclusive shelf")))
+			continue
+		}
+		if existing[bookKey(title, "")] {
+			plan.Skipped = append(plan.Skipped, i18n.T(lang, "line %d: \"%s\" is already on the shelf", line, title))
+			continue
+		}
+		existing[bookKey(title, "")] = true
+		book := database.ShelfBook{UserName: target, Title: title, Author: h.get(row, "author"), Shelf: shelf}
+		book.Progress = database.ReadingProgress{UserName: target}
+		if shelf == database.Read {
+			book.Progress.Type = database.RegularBook
+			book.Progress.Progress = 100
+			if pages, err := strconv.Atoi(h.get(row, "number of pages")); err == nil && pages > 0 {
+				book.Progress.TotalPages = pages
+				book.Progress.PageNumber = pages
+			}
+		}
+		plan.Shelf = append(plan.Shelf, book)
+	}
+	return plan
+}
+
+func bookKey(title, author string) string {
+	return strings.ToLower(strings.TrimSpace(title)) + "|" + strings.ToLower(strings.TrimSpace(author))
+}
+
+// previewLimit caps every list of the preview, so that it fits in one Telegram
+// message even for a Goodreads library with hundreds of books.
+const previewLimit = 20
+
+// more tells how many of total entries the preview left out, or is empty.
+func more(lang string, total int) string {
+	if total <= previewLimit {
+		return ""
+	}
+	return i18n.N(lang, total-previewLimit, "…and %d more", "…and %d more", total-previewLimit) + "\n"
+}
+
+// Preview describes the plan for the dry run, listing the first previewLimit
+// entries of each kind.
+func (p Plan) Preview(lang string) string {
+	result := i18n.T(lang, "Dry run - nothing is saved yet.") + "\n"
+	switch p.Kind {
+	case Members:
+		result += "\n" + i18n.N(lang, len(p.Members), "%d member will be added:", "%d members will be added:", len(p.Members)) + "\n"
+		for _, user := range p.Members[:min(len(p.Members), previewLimit)] {
+			result += "+ @" + user.UserName + " - " + user.FullName
+			if user.IsAdmin {
+				result += " (" + i18n.T(lang, "admin") + ")"
+			}
+			result += "\n"
+		}
+		result += more(lang, len(p.Members))
+	case Books:
+		result += "\n" + i18n.N(lang, len(p.Books), "%d past book will be added:", "%d past books will be added:", len(p.Books)) + "\n"
+		for _, book := range p.Books[:min(len(p.Books), previewLimit)] {
+			result += "+ " + i18n.T(lang, "%s by %s", book.Title, book.Author)
+			if book.MeetingDate != "" {
+				result += i18n.T(lang, ", meeting %s", book.MeetingDate)
+			}
+			result += "\n"
+		}
+		result += more(lang, len(p.Books))
+	case Goodreads:
+		result += "\n" + i18n.N(lang, len(p.Shelf), "%d book will be added to the shelf of @%s:", "%d books will be added to the shelf of @%s:", len(p.Shelf), p.Target) + "\n"
+		for _, book := range p.Shelf[:min(len(p.Shelf), previewLimit)] {
+			result += "+ " + book.Title + " [" + book.Shelf.Label(lang) + "]\n"
+		}
+		result += more(lang, len(p.Shelf))
+	}
+	if len(p.Skipped) > 0 {
+		result += "\n" + i18n.N(lang, len(p.Skipped), "%d row will be skipped:", "%d rows will be skipped:", len(p.Skipped)) + "\n"
+		for _, reason := range p.Skipped[:min(len(p.Skipped), previewLimit)] {
+			result += "- " + reason + "\n"
+		}
+		result += more(lang, len(p.Skipped))
+	}
+	return result
+}
+
+// Empty reports whether the import would not create anything.
+func (p Plan) Empty() bool {
+	return len(p.Members) == 0 && len(p.Books) == 0 && len(p.Shelf) == 0
+}
+
+// Apply carries out the plan on behalf of actor and returns the number of records created.
+func (p Plan) Apply(actor string) int {
+	for _, user := range p.Members {
+		created := database.CreateUser(user.UserName, user.FullName, user.IsAdmin)
+		database.RecordAudit(actor, database.ActionAddUser, user.UserName, nil, created)
+	}
+	for _, book := range p.Books {
+		database.AddPastBook(book)
+	}
+	base := time.Now().UnixNano()
+	for i, book := range p.Shelf {
+		// Nanosecond IDs keep the Goodreads order on the shelf.
+		book.ShelfID = strconv.FormatInt(base+int64(i), 10)
+		book.Progress.BookID = book.ShelfID
+		database.PutShelfBook(book)
+	}
+	return len(p.Members) + len(p.Books) + len(p.Shelf)
+}
@@ -0,0 +1,28 @@
+package bulkimport
+
+import (
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPreviewFitsInOneMessage(t *testing.T) {
+	plan := Plan{Kind: Goodreads, Target: "alice"}
+	for i := 0; i < 500; i++ {
+		title := "A rather long book title to fill the preview, volume " + strconv.Itoa(i)
+		plan.Shelf = append(plan.Shelf, database.ShelfBook{Title: title, Shelf: database.Read})
+		plan.Skipped = append(plan.Skipped, "line "+strconv.Itoa(i)+": \""+title+"\" is already on the shelf")
+	}
+	preview := plan.Preview("en")
+	if length := utf8.RuneCountInString(preview); length > 4096 {
+		t.Errorf("preview is %d characters, more than a Telegram message holds", length)
+	}
+	if !strings.Contains(preview, "500 books will be added") || strings.Count(preview, "…and 480 more") != 2 {
+		t.Errorf("preview doesn't count the left out entries:\n%s", preview)
+	}
+	if strings.Contains(preview, "volume 20\n") || !strings.Contains(preview, "volume 19 [") {
+		t.Errorf("preview should list exactly the first %d books:\n%s", previewLimit, preview)
+	}
+}
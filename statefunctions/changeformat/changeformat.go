@@ -0,0 +1,127 @@
+package changeformat
+
+import (
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/setprogress"
+	"telegram-bot/utils"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func ChangeFormatDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	userProgress := database.UserProgress(user)
+	if userProgress == nil || userProgress.Type == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "You haven't set your progress for the current book yet. Use /setProgress first.")))
+		return
+	}
+	database.SetUserStatus(user, "enter_new_book_type")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "You are currently reading the %s version. Select the new format:", i18n.T(lang, string(userProgress.Type))))
+	msg.ReplyMarkup = setprogress.BookTypeKeyboard(lang)
+	bot.Send(msg)
+}
+
+func EnterNewBookType(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	bookType, ok := setprogress.ParseBookType(update.Message.Text)
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, I didn't understand you. Please select the book type - regular, e-book, kindle or audio:")))
+		return
+	}
+	userProgress, ok := setprogress.CurrentProgress(user, bot, update)
+	if !ok {
+		return
+	}
+	if userProgress.Type == bookType {
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "You are already reading this format. Use /setProgress to update your progress.")))
+		return
+	}
+	switch bookType {
+	case database.EBook:
+		convert(user, userProgress, bookType, 0, bot, update)
+	case database.RegularBook:
+		database.SetUserStatus(user, "enter_new_total_pages")
+		bot.Send(setprogress.TotalPagesPrompt(update.Message.Chat.ID, database.GetCurrentBook(), lang))
+	case database.KindleBook:
+		database.SetUserStatus(user, "enter_new_total_locations")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter total Kindle locations of the book:")))
+	case database.AudioBook:
+		database.SetUserStatus(user, "enter_new_total_duration")
+		bot.Send(setprogress.TotalDurationPrompt(update.Message.Chat.ID, database.GetCurrentBook(), lang))
+	}
+}
+
+func EnterNewTotalPages(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	total, ok := setprogress.ParseTotalPages(lang, bot, update)
+	if !ok {
+		return
+	}
+	convertCurrent(user, database.RegularBook, total, bot, update)
+}
+
+func EnterNewTotalLocations(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	total, ok := enterTotal(database.UserLanguage(user), bot, update)
+	if !ok {
+		return
+	}
+	convertCurrent(user, database.KindleBook, total, bot, update)
+}
+
+func EnterNewTotalDuration(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	total, err := utils.ParseDuration(update.Message.Text)
+	if err != nil || total <= 0 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter the duration in format hh:mm, e.g. 09:45.")))
+		return
+	}
+	convertCurrent(user, database.AudioBook, total, bot, update)
+}
+
+func enterTotal(lang string, bot *tgbotapi.BotAPI, update tgbotapi.Update) (int, bool) {
+	total, err := strconv.Atoi(strings.TrimSpace(update.Message.Text))
+	if err != nil {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter a number.")))
+		return 0, false
+	}
+	if total <= 0 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter a number greater than 0.")))
+		return 0, false
+	}
+	return total, true
+}
+
+// convertCurrent converts the member's progress in the current book, which may be
+// gone by the time the new total is entered.
+func convertCurrent(user string, bookType database.BookType, total int, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	userProgress, ok := setprogress.CurrentProgress(user, bot, update)
+	if !ok {
+		return
+	}
+	convert(user, userProgress, bookType, total, bot, update)
+}
+
+// convert keeps the member's percent and translates it into a position in the new format,
+// so the group report shows no jump when somebody switches from paper to audio.
+func convert(user string, userProgress *database.ReadingProgress, bookType database.BookType, total int, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	converted := userProgress.ConvertTo(bookType, total)
+	database.SetProgress(converted)
+	database.SetUserStatus(user, "")
+
+	message := i18n.T(lang, "Your reading format is now %s.", i18n.T(lang, string(bookType)))
+	from := userProgress.Position(lang)
+	if from == "" {
+		from = strconv.Itoa(userProgress.Progress) + "%"
+	}
+	to := converted.Position(lang)
+	if to == "" {
+		to = strconv.Itoa(converted.Progress) + "%"
+	}
+	message += "\n" + i18n.T(lang, "Your progress was converted: %s → %s.", from, to)
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, message))
+}
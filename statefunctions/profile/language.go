@@ -0,0 +1,38 @@
+package profile
+
+import (
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// LanguageDefault answers /language by offering the supported languages.
+func LanguageDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserStatus(user, "enter_language")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Choose your language:"))
+	msg.ReplyMarkup = LanguageKeyboard()
+	bot.Send(msg)
+}
+
+func SetClubLanguageDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserStatus(user, "enter_club_language")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The club language is %s. It is used for members who haven't chosen their own. Choose the new language:", languageName(database.ClubLanguage())))
+	msg.ReplyMarkup = LanguageKeyboard()
+	bot.Send(msg)
+}
+
+func EnterClubLanguage(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	language, ok := Languages[strings.TrimSpace(update.Message.Text)]
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please choose a language on the keyboard.")))
+		return
+	}
+	database.SetClubSetting("Language", language)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(database.UserLanguage(user), "The club language is now %s.", languageName(language))))
+}
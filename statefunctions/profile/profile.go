@@ -0,0 +1,157 @@
+package profile
+
+import (
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/gamification"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/settimezone"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	editName         = "Display name"
+	editTimezone     = "Timezone"
+	editLanguage     = "Language"
+	toggleMilestones = "Milestone reminders"
+	toggleRatings    = "Rating requests"
+	done             = "Done"
+)
+
+// Languages maps the language buttons to language codes.
+var Languages = map[string]string{
+	"English": "en",
+	"Русский": "ru",
+}
+
+// ProfileDefault shows the member's profile and offers to edit the preferences.
+func ProfileDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	details := database.GetUserDetails(user)
+	lang := details.Lang()
+	stats := gamification.MemberProfile(user)
+
+	text := details.FullName + " (@" + user + ")\n\n"
+	text += i18n.T(lang, "Books read with the club: %d", stats.BooksFinished) + "\n"
+	if stats.BooksStarted > 0 {
+		text += i18n.T(lang, "Completion rate: %d%% (%d of %d started)", stats.CompletionRate(), stats.BooksFinished, stats.BooksStarted) + "\n"
+	}
+	if stats.PercentPerDay > 0 {
+		text += i18n.T(lang, "Average pace: %.1f%% of a book per day", stats.PercentPerDay) + "\n"
+	}
+	if stats.FavouriteFormat != "" {
+		text += i18n.T(lang, "Favourite format: ") + i18n.T(lang, string(stats.FavouriteFormat)) + "\n"
+	}
+	if stats.RatingsGiven > 0 {
+		text += i18n.T(lang, "Ratings given: %d (average %.1f/5)", stats.RatingsGiven, stats.AverageRating) + "\n"
+	}
+	text += i18n.T(lang, "Current streak: %s (best %d)", i18n.N(lang, stats.Streak, "%d day", "%d days", stats.Streak), stats.BestStreak) + "\n"
+
+	timezone := details.Timezone
+	if timezone == "" {
+		timezone = database.ClubTimezone() + " " + i18n.T(lang, "(club)")
+	}
+	language := i18n.T(lang, "not set")
+	if details.Language != "" {
+		language = languageName(details.Language)
+	}
+	text += "\n" + i18n.T(lang, "Preferences:") + "\n"
+	text += i18n.T(lang, "Timezone: ") + timezone + "\n"
+	text += i18n.T(lang, "Language: ") + language + "\n"
+	text += i18n.T(lang, toggleMilestones) + ": " + onOff(lang, !details.NoMilestoneReminders) + "\n"
+	text += i18n.T(lang, toggleRatings) + ": " + onOff(lang, !details.NoRatingRequests) + "\n"
+	text += "\n" + i18n.T(lang, "Choose a setting to change it.")
+
+	database.SetUserStatus(user, "select_profile_setting")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, text)
+	keyboard := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, editName)),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, editTimezone)),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, editLanguage)),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, toggleMilestones)),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, toggleRatings)),
+		),
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, done))),
+	)
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func SelectProfileSetting(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	details := database.GetUserDetails(user)
+	switch strings.TrimSpace(update.Message.Text) {
+	case i18n.T(lang, editName):
+		database.SetUserStatus(user, "enter_display_name")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter your new display name:")))
+	case i18n.T(lang, editTimezone):
+		settimezone.SetUserTimezoneDefault(user, bot, update)
+	case i18n.T(lang, editLanguage):
+		LanguageDefault(user, bot, update)
+	case i18n.T(lang, toggleMilestones):
+		database.UpdateUserField(user, "NoMilestoneReminders", !details.NoMilestoneReminders)
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, toggleMilestones)+": "+onOff(lang, details.NoMilestoneReminders)+"."))
+	case i18n.T(lang, toggleRatings):
+		database.UpdateUserField(user, "NoRatingRequests", !details.NoRatingRequests)
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, toggleRatings)+": "+onOff(lang, details.NoRatingRequests)+"."))
+	default:
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Your profile is saved.")))
+	}
+}
+
+func EnterDisplayName(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	name := strings.TrimSpace(update.Message.Text)
+	if name == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter a name.")))
+		return
+	}
+	database.SetUserDisplayName(user, name)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Your display name is now %s.", name)))
+}
+
+func EnterLanguage(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	language, ok := Languages[strings.TrimSpace(update.Message.Text)]
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please choose a language on the keyboard.")))
+		return
+	}
+	database.SetUserLanguage(user, language)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(language, "Language saved.")))
+}
+
+func LanguageKeyboard() tgbotapi.ReplyKeyboardMarkup {
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(
+		tgbotapi.NewKeyboardButton("English"),
+		tgbotapi.NewKeyboardButton("Русский"),
+	))
+	keyboard.OneTimeKeyboard = true
+	return keyboard
+}
+
+// languageName returns the keyboard label of a language code.
+func languageName(code string) string {
+	for name, language := range Languages {
+		if language == code {
+			return name
+		}
+	}
+	return code
+}
+
+func onOff(lang string, on bool) string {
+	if on {
+		return i18n.T(lang, "on")
+	}
+	return i18n.T(lang, "off")
+}
@@ -0,0 +1,181 @@
+package queuebook
+
+import (
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/dateparse"
+	"telegram-bot/i18n"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const skip = "Skip"
+
+func QueueBookDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserStatus(user, "enter_queue_title")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the name of the book to add to the queue:")))
+}
+
+func EnterQueueTitle(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	title := strings.TrimSpace(update.Message.Text)
+	if title == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter the name of the book.")))
+		return
+	}
+	database.SetUserDraft(user, database.QueueBook(title))
+	database.SetUserStatus(user, "enter_queue_author")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the author of the book:")))
+}
+
+func EnterQueueAuthor(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.UpdateBookAuthor(database.UserDraft(user), strings.TrimSpace(update.Message.Text))
+	database.SetUserStatus(user, "enter_queue_start")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "When do we plan to start it? E.g. 01.12.2026 or in 4 weeks:"))
+	msg.ReplyMarkup = skipKeyboard(lang)
+	bot.Send(msg)
+}
+
+func EnterQueueStart(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	date, ok := parsePlannedDate(lang, bot, update)
+	if !ok {
+		return
+	}
+	if date != "" {
+		database.UpdateBookField(database.UserDraft(user), "PlannedStart", date)
+	}
+	database.SetUserStatus(user, "enter_queue_meeting")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "When is the meeting planned? E.g. 20.12.2026 or in 6 weeks:"))
+	msg.ReplyMarkup = skipKeyboard(lang)
+	bot.Send(msg)
+}
+
+func EnterQueueMeeting(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	date, ok := parsePlannedDate(lang, bot, update)
+	if !ok {
+		return
+	}
+	bookID := database.UserDraft(user)
+	if date != "" {
+		database.UpdateBookField(bookID, "PlannedMeeting", date)
+	}
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	book := database.GetBook(bookID)
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "\"%s\" is number %d in the queue. See /upcoming.", book.Title, book.QueuePosition)))
+}
+
+// parsePlannedDate returns the date as dd.mm.yyyy, or "" if the admin skipped it.
+func parsePlannedDate(lang string, bot *tgbotapi.BotAPI, update tgbotapi.Update) (string, bool) {
+	text := strings.TrimSpace(update.Message.Text)
+	if text == i18n.T(lang, skip) {
+		return "", true
+	}
+	parsed, err := dateparse.Parse(text, time.Now().In(database.ClubLocation()))
+	if err != nil {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, I couldn't understand the date. Please enter it like 25.12.2026, 25 December or in 3 weeks:")))
+		return "", false
+	}
+	return parsed.Date.Format("02.01.2006"), true
+}
+
+func ReorderQueueDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	queue := database.QueuedBooks()
+	if len(queue) < 2 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "There is nothing to reorder. Add books with /queueBook.")))
+		return
+	}
+	database.SetUserStatus(user, "enter_queue_order")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, FormatQueue(queue, lang)+"\n"+i18n.T(lang, "Enter the book ID and its new position, e.g. %s 1", queue[len(queue)-1].ShortID())))
+}
+
+func EnterQueueOrder(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	fields := strings.Fields(update.Message.Text)
+	if len(fields) != 2 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter the book ID and the new position separated by a space.")))
+		return
+	}
+	position, err := strconv.Atoi(fields[1])
+	if err != nil || position < 1 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The position must be a number greater than 0.")))
+		return
+	}
+	book, ok := findQueued(strings.TrimPrefix(fields[0], "#"))
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "There is no such book in the queue. Please enter the ID from the list.")))
+		return
+	}
+	database.MoveQueuedBook(book.BookID, position)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, FormatQueue(database.QueuedBooks(), lang)))
+}
+
+func UnqueueBookDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	queue := database.QueuedBooks()
+	if len(queue) == 0 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The queue is empty.")))
+		return
+	}
+	database.SetUserStatus(user, "enter_book_to_unqueue")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, FormatQueue(queue, lang)+"\n"+i18n.T(lang, "Enter the ID of the book to remove from the queue:")))
+}
+
+func EnterBookToUnqueue(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	book, ok := findQueued(strings.TrimPrefix(strings.TrimSpace(update.Message.Text), "#"))
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "There is no such book in the queue. Please enter the ID from the list.")))
+		return
+	}
+	database.UnqueueBook(book.BookID)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "\"%s\" was removed from the queue.", book.Title)))
+}
+
+func findQueued(id string) (database.Book, bool) {
+	for _, book := range database.QueuedBooks() {
+		if book.ShortID() == id || book.BookID == id {
+			return book, true
+		}
+	}
+	return database.Book{}, false
+}
+
+// FormatQueue lists the upcoming books with their planned dates.
+func FormatQueue(queue []database.Book, lang string) string {
+	if len(queue) == 0 {
+		return i18n.T(lang, "No upcoming books are planned yet.") + "\n"
+	}
+	result := i18n.T(lang, "Upcoming books:") + "\n"
+	for i, book := range queue {
+		result += strconv.Itoa(i+1) + ". #" + book.ShortID() + " "
+		if book.Author != "" {
+			result += i18n.T(lang, "%s by %s", book.Title, book.Author)
+		} else {
+			result += book.Title
+		}
+		result += "\n"
+		if book.PlannedStart != "" {
+			result += "   " + i18n.T(lang, "start: ") + book.PlannedStart + "\n"
+		}
+		if book.PlannedMeeting != "" {
+			result += "   " + i18n.T(lang, "meeting: ") + book.PlannedMeeting + "\n"
+		}
+	}
+	return result
+}
+
+func skipKeyboard(lang string) tgbotapi.ReplyKeyboardMarkup {
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, skip))))
+	keyboard.OneTimeKeyboard = true
+	return keyboard
+}
@@ -0,0 +1,102 @@
+package ratebook
+
+import (
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	later = "Later"
+	skip  = "Skip"
+)
+
+// RateBookDefault rates the current book, or the book given as the command argument.
+func RateBookDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	book := database.GetCurrentBook()
+	if arguments := strings.TrimSpace(update.Message.CommandArguments()); arguments != "" {
+		matches := database.FindBook(arguments)
+		if len(matches) != 1 {
+			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No such book. Use /history to find the book ID.")))
+			return
+		}
+		book = matches[0]
+	}
+	if book.BookID == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No active book found.")))
+		return
+	}
+	AskForRating(user, book, bot, update.Message.Chat.ID)
+}
+
+// AskForRating starts the rating dialog for a book in the given chat.
+func AskForRating(user string, book database.Book, bot *tgbotapi.BotAPI, chatID int64) {
+	lang := database.UserLanguage(user)
+	database.SetUserDraft(user, book.BookID)
+	database.SetUserStatus(user, "enter_rating")
+	msg := tgbotapi.NewMessage(chatID, i18n.T(lang, "How would you rate \"%s\" from 1 to 5?", book.Title))
+	keyboard := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton("1"),
+			tgbotapi.NewKeyboardButton("2"),
+			tgbotapi.NewKeyboardButton("3"),
+			tgbotapi.NewKeyboardButton("4"),
+			tgbotapi.NewKeyboardButton("5"),
+		),
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, later))),
+	)
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func EnterRating(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	if text == i18n.T(lang, later) {
+		database.SetUserDraft(user, "")
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "OK, you can rate it later with /rate.")))
+		return
+	}
+	rating, err := strconv.Atoi(text)
+	if err != nil || rating < 1 || rating > 5 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter a number from 1 to 5.")))
+		return
+	}
+	bookID := database.UserDraft(user)
+	database.SetRating(database.Rating{BookID: bookID, UserName: user, Rating: rating})
+	database.SetUserDraft(user, bookID+"|"+text)
+	database.SetUserStatus(user, "enter_review")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Thank you! Would you like to add a short review?"))
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, skip))))
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func EnterReview(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	bookID, ratingText, _ := strings.Cut(database.UserDraft(user), "|")
+	if text != i18n.T(lang, skip) && text != "" {
+		rating, _ := strconv.Atoi(ratingText)
+		database.SetRating(database.Rating{BookID: bookID, UserName: user, Rating: rating, Review: text})
+	}
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Thank you for your feedback!")))
+}
+
+// FormatRating summarises the club's ratings, e.g. "4.3/5 (6 ratings)", or "" if there are none.
+func FormatRating(ratings []database.Rating, lang string) string {
+	average, count := database.AverageRating(ratings)
+	if count == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(average, 'f', 1, 64) + "/5 (" + i18n.N(lang, count, "%d rating", "%d ratings", count) + ")"
+}
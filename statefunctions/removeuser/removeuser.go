@@ -2,18 +2,26 @@ package removeuser
 
 import (
 	"telegram-bot/database"
+	"telegram-bot/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func RemoveUserDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	database.SetUserStatus(user, "enter_nickname_to_remove")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter user telegram nick name:"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter user telegram nick name:")))
 }
 
 func RemoveUser(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	userNickName := update.Message.Text
+	var before interface{}
+	if details := database.GetUserDetails(userNickName); details.UserName != "" {
+		before = details
+	}
 	database.RemoveUser(userNickName)
+	database.RecordAudit(user, database.ActionRemoveUser, userNickName, before, nil)
 	database.SetUserStatus(user, "")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "User removed successfully!"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "User removed successfully!")))
 }
@@ -0,0 +1,54 @@
+package setactivebook
+
+import (
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func SetActiveBookDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if arguments := strings.TrimSpace(update.Message.CommandArguments()); arguments != "" {
+		database.SetUserStatus(user, "enter_book_to_activate")
+		update.Message.Text = arguments
+		EnterBookToActivate(user, bot, update)
+		return
+	}
+	database.SetUserStatus(user, "enter_book_to_activate")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the ID or the title of the book:")))
+}
+
+func EnterBookToActivate(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	query := strings.TrimSpace(update.Message.Text)
+	if id, ok := database.ParseBookChoice(query); ok {
+		query = id
+	}
+	books := database.FindBook(query)
+	switch len(books) {
+	case 0:
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No book found. Please enter the ID or the title of the book (see /getBookList):")))
+	case 1:
+		book := books[0]
+		if book.Active {
+			database.SetUserStatus(user, "")
+			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "\"%s\" is already the current book.", book.Title)))
+			return
+		}
+		database.SetActiveBook(book.BookID)
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "\"%s\" is now the current book. Use /updateMeetingDate to set the meeting date.", book.Title)))
+	default:
+		var rows [][]tgbotapi.KeyboardButton
+		for _, book := range books {
+			rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(database.FormatBookChoice(book))))
+		}
+		keyboard := tgbotapi.NewReplyKeyboard(rows...)
+		keyboard.OneTimeKeyboard = true
+		msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Several books match. Which one?"))
+		msg.ReplyMarkup = keyboard
+		bot.Send(msg)
+	}
+}
@@ -1,65 +1,244 @@
 package setbook
 
 import (
-	"regexp"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"telegram-bot/bookinfo"
 	"telegram-bot/database"
+	"telegram-bot/dateparse"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// bookSearch is kept in the user's draft between the search and the selection of a result.
+type bookSearch struct {
+	Query   string            `json:"query"`
+	Results []bookinfo.Result `json:"results"`
+}
+
+const enterManually = "Enter manually"
+
+const datePrompt = "Enter the date, e.g. 25.12.2026, 25 December, next Friday 19:00 or in 3 weeks:"
+
 func SetBookDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	database.SetUserStatus(user, "enter_book_name")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter the name of the book:"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the name of the book, its ISBN or a search query:")))
 }
 
 func EnterBookName(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	bookName := update.Message.Text
-	database.AddBook(bookName)
+	lang := database.UserLanguage(user)
+	query := strings.TrimSpace(update.Message.Text)
+	results, err := bookinfo.FromEnv().Search(query)
+	if err != nil {
+		log.Printf("Book lookup failed: %s", err)
+		database.SetUserStatus(user, "enter_book_title")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, the book lookup failed. Please enter the title of the book:")))
+		return
+	}
+	if len(results) == 0 {
+		addBookFromQuery(user, query, bot, update)
+		return
+	}
+
+	draft, err := json.Marshal(bookSearch{Query: query, Results: results})
+	if err != nil {
+		log.Fatalf("Failed to marshal book search: %s", err)
+	}
+	database.SetUserDraft(user, string(draft))
+	database.SetUserStatus(user, "select_book_result")
+
+	var rows [][]tgbotapi.KeyboardButton
+	for i, result := range results {
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(resultLabel(i, result))))
+	}
+	rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, enterManually))))
+	keyboard := tgbotapi.NewReplyKeyboard(rows...)
+	keyboard.OneTimeKeyboard = true
+
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Select the book:"))
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func SelectBookResult(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	var search bookSearch
+	if err := json.Unmarshal([]byte(database.UserDraft(user)), &search); err != nil {
+		log.Fatalf("Failed to unmarshal book search: %s", err)
+	}
+
+	text := strings.TrimSpace(update.Message.Text)
+	if text == i18n.T(lang, enterManually) {
+		database.SetUserDraft(user, "")
+		addBookFromQuery(user, search.Query, bot, update)
+		return
+	}
+	number, err := strconv.Atoi(strings.SplitN(text, ".", 2)[0])
+	if err != nil || number < 1 || number > len(search.Results) {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please choose a book on the keyboard, or \"%s\" to type it in yourself.", i18n.T(lang, enterManually))))
+		return
+	}
+	database.SetUserDraft(user, "")
+
+	result := search.Results[number-1]
+	currentBook := addBook(user, result.Title)
+	database.UpdateBookAuthor(currentBook.BookID, result.Author)
+	if result.Pages > 0 {
+		database.UpdateBookField(currentBook.BookID, "Editions", []database.Edition{{Pages: result.Pages}})
+	}
+	if result.ISBN != "" {
+		database.UpdateBookField(currentBook.BookID, "ISBN", result.ISBN)
+	}
+	if result.Year != 0 {
+		database.UpdateBookField(currentBook.BookID, "Year", result.Year)
+	}
+	if result.CoverURL != "" {
+		database.UpdateBookField(currentBook.BookID, "CoverURL", result.CoverURL)
+	}
+	database.SetUserStatus(user, "enter_finishing_date")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Added \"%s\" by %s. Use /setBookInfo to fill in anything missing.", result.Title, result.Author)+"\n"+i18n.T(lang, "Enter date of club's meeting. ")+i18n.T(lang, datePrompt)))
+}
+
+// addBookFromQuery adds the book with the search query as its title, unless the
+// query was an ISBN, in which case the admin is asked for the title.
+func addBookFromQuery(user, query string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if _, isISBN := bookinfo.NormalizeISBN(query); isISBN {
+		database.SetUserStatus(user, "enter_book_title")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Nothing was found for ISBN %s. Please enter the title of the book:", query)))
+		return
+	}
+	addBookManually(user, query, bot, update)
+}
+
+func EnterBookTitle(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	title := utils.NormalizeQuotes(strings.TrimSpace(update.Message.Text))
+	if title == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter the title of the book:")))
+		return
+	}
+	addBookManually(user, title, bot, update)
+}
+
+func addBookManually(user, title string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	addBook(user, title)
 	database.SetUserStatus(user, "enter_author")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter the author of the book:"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the author of the book:")))
+}
+
+// addBook makes a new book the active one and records it in the audit log
+// together with the book it replaced.
+func addBook(user, title string) database.Book {
+	var previous interface{}
+	if book := database.GetCurrentBook(); book.BookID != "" {
+		previous = book
+	}
+	database.AddBook(title)
+	book := database.GetCurrentBook()
+	database.RecordAudit(user, database.ActionAddBook, book.BookID, previous, book)
+	return book
+}
+
+func resultLabel(i int, result bookinfo.Result) string {
+	label := strconv.Itoa(i+1) + ". " + result.Title
+	if result.Author != "" {
+		label += " - " + result.Author
+	}
+	if result.Year != 0 {
+		label += " (" + strconv.Itoa(result.Year) + ")"
+	}
+	return label
 }
 
 func EnterAuthor(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	author := update.Message.Text
 	currentBook := database.GetCurrentBook()
 	database.UpdateBookAuthor(currentBook.BookID, author)
 	database.SetUserStatus(user, "enter_finishing_date")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter date of club's meeting. Format 'dd.mm.yyyy'"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter date of club's meeting. ")+i18n.T(lang, datePrompt)))
 }
 
 func EnterFinishingDate(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	date := update.Message.Text
-	re := regexp.MustCompile(`^\d{2}\.\d{2}\.\d{4}$`)
-
-	if re.MatchString(date) {
-		// Parse the date to check if it's valid
-		parsedDate, err := time.Parse("02.01.2006", date)
-		if err != nil {
-			// If the date is invalid, ask the user to input it again
-			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Invalid date format. Please enter the date in format dd.mm.yyyy:"))
-			return
-		}
+	lang := database.UserLanguage(user)
+	// Parse the date in the club's timezone to check if it's valid
+	clubLocation := database.ClubLocation()
+	now := time.Now().In(clubLocation)
+	parsed, err := dateparse.Parse(update.Message.Text, now)
+	if err != nil {
+		// If the date can't be understood, ask the user to input it again
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, I couldn't understand the date. ")+i18n.T(lang, datePrompt)))
+		return
+	}
 
-		// Check if the date is later than today
-		currentDate := time.Now()
-		if parsedDate.Before(currentDate) || parsedDate.Equal(currentDate) {
-			bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "The date must be later than today. Please enter a valid later date in format dd.mm.yyyy:"))
-			return
-		}
+	// Check if the date is later than today in the club's timezone
+	if utils.DaysBetween(now, parsed.Date) < 1 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The date must be later than today. Please enter a valid later date:")))
+		return
+	}
 
-		// If the date is valid and later than today, store it and thank the user
+	// Echo the interpreted date back before saving it
+	draft := parsed.Date.Format("02.01.2006")
+	if parsed.HasTime {
+		draft += " " + parsed.Date.Format("15:04")
+	}
+	database.SetUserDraft(user, draft)
+	database.SetUserStatus(user, "confirm_finishing_date")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The meeting will be on %s. Is that right?", dateparse.Format(parsed, lang)))
+	msg.ReplyMarkup = confirmKeyboard(lang)
+	bot.Send(msg)
+}
+
+func ConfirmFinishingDate(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	answer := strings.TrimSpace(update.Message.Text)
+	switch {
+	case strings.EqualFold(answer, i18n.T(lang, "Yes")):
+		date, meetingTime, _ := strings.Cut(database.UserDraft(user), " ")
 		currentBook := database.GetCurrentBook()
 		database.UpdateBookDate(currentBook.BookID, date)
+		updated := currentBook
+		updated.MeetingDate = date
+		database.RecordAudit(user, database.ActionUpdateBookDate, currentBook.BookID, currentBook, updated)
+		database.SetFinalMeetingDate(currentBook.BookID, date)
+		if meetingTime != "" {
+			database.UpdateMeetingField(database.FinalMeetingID(currentBook.BookID), "Time", meetingTime)
+		}
+		database.SetUserDraft(user, "")
 		database.SetUserStatus(user, "")
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Thank you!"))
-	} else {
-		// If the format is incorrect, ask the user to input it again
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Invalid date format. Please enter the date in format dd.mm.yyyy:"))
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Thank you!")))
+	case strings.EqualFold(answer, i18n.T(lang, "No")):
+		database.SetUserDraft(user, "")
+		database.SetUserStatus(user, "enter_finishing_date")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, datePrompt)))
+	default:
+		// Anything else is taken as a corrected date
+		database.SetUserStatus(user, "enter_finishing_date")
+		EnterFinishingDate(user, bot, update)
 	}
 }
 
+func confirmKeyboard(lang string) tgbotapi.ReplyKeyboardMarkup {
+	keyboard := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Yes")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "No")),
+		),
+	)
+	keyboard.OneTimeKeyboard = true
+	return keyboard
+}
+
 func UpdateBookDateDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	database.SetUserStatus(user, "enter_finishing_date")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter date of club's meeting. Format 'dd.mm.yyyy'"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter date of club's meeting. ")+i18n.T(lang, datePrompt)))
 }
@@ -0,0 +1,197 @@
+package setbookinfo
+
+import (
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// fields maps keyboard labels to the Book attributes they edit.
+var fields = map[string]string{
+	"ISBN":               "ISBN",
+	"Pages":              "Editions",
+	"Audiobook duration": "AudioMinutes",
+	"Genre":              "Genre",
+	"Cover":              "CoverURL",
+	"Description":        "Description",
+	"Year":               "Year",
+	"Chapters":           "Chapters",
+}
+
+var prompts = map[string]string{
+	"ISBN":         "Enter the ISBN (10 or 13 digits):",
+	"Editions":     "Enter the page count. For several editions send one per line, e.g.\nHardcover: 320\nPaperback: 352",
+	"AudioMinutes": "Enter the audiobook duration (hh:mm):",
+	"Genre":        "Enter the genre:",
+	"CoverURL":     "Enter the cover image URL:",
+	"Description":  "Enter a short description:",
+	"Year":         "Enter the publication year:",
+	"Chapters":     "Enter one chapter per line with the page it starts on in the first edition, e.g.\nChapter 1: 1\nChapter 2: 24",
+}
+
+func SetBookInfoDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	currentBook := database.GetCurrentBook()
+	if currentBook.BookID == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No active book found.")))
+		return
+	}
+	database.SetUserStatus(user, "select_book_info_field")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "What do you want to set for \"%s\"?", currentBook.Title))
+	keyboard := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "ISBN")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Pages")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Audiobook duration")),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Genre")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Year")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Chapters")),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Cover")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Description")),
+		),
+	)
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func SelectBookInfoField(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	field, ok := parseField(lang, strings.TrimSpace(update.Message.Text))
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please select one of the fields on the keyboard.")))
+		return
+	}
+	database.SetUserDraft(user, field)
+	database.SetUserStatus(user, "enter_book_info_value")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, prompts[field])))
+}
+
+// parseField accepts a keyboard label in the admin's language or in English.
+func parseField(lang, text string) (string, bool) {
+	for label, field := range fields {
+		if strings.EqualFold(text, i18n.T(lang, label)) || strings.EqualFold(text, label) {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+func EnterBookInfoValue(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	field := database.UserDraft(user)
+	value, problem := parseValue(field, strings.TrimSpace(update.Message.Text))
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, problem)))
+		return
+	}
+	currentBook := database.GetCurrentBook()
+	database.UpdateBookField(currentBook.BookID, field, value)
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Thank you! Use /setBookInfo to set another field.")))
+}
+
+// parseValue converts the admin's text into the attribute value,
+// returning a message to send back when the text is not valid.
+func parseValue(field, text string) (interface{}, string) {
+	switch field {
+	case "ISBN":
+		isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(text))
+		if !utils.IsValidISBN(isbn) {
+			return nil, "Please enter a valid ISBN (10 or 13 digits)."
+		}
+		return isbn, ""
+	case "Editions":
+		editions, ok := ParseEditions(text)
+		if !ok {
+			return nil, "Please enter the page count as a number, or one \"Edition: pages\" per line."
+		}
+		return editions, ""
+	case "AudioMinutes":
+		minutes, err := utils.ParseDuration(text)
+		if err != nil || minutes <= 0 {
+			return nil, "Please enter the duration in format hh:mm, e.g. 09:45."
+		}
+		return minutes, ""
+	case "CoverURL":
+		if !strings.HasPrefix(text, "http://") && !strings.HasPrefix(text, "https://") {
+			return nil, "Please enter a link starting with http:// or https://."
+		}
+		return text, ""
+	case "Year":
+		year, err := strconv.Atoi(text)
+		if err != nil || year <= 0 || year > time.Now().Year()+1 {
+			return nil, "Please enter a valid year."
+		}
+		return year, ""
+	case "Chapters":
+		chapters, ok := ParseChapters(text)
+		if !ok {
+			return nil, "Please enter one \"Chapter name: start page\" per line, with the pages in increasing order."
+		}
+		return chapters, ""
+	case "Genre", "Description":
+		if text == "" {
+			return nil, "Please enter some text."
+		}
+		return text, ""
+	}
+	return nil, "Please start again with /setBookInfo."
+}
+
+// ParseEditions reads either a single page count or "Name: pages" lines.
+func ParseEditions(text string) ([]database.Edition, bool) {
+	var editions []database.Edition
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name := ""
+		if i := strings.LastIndex(line, ":"); i >= 0 {
+			name = strings.TrimSpace(line[:i])
+			line = line[i+1:]
+		}
+		pages, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || pages <= 0 {
+			return nil, false
+		}
+		editions = append(editions, database.Edition{Name: name, Pages: pages})
+	}
+	return editions, len(editions) > 0
+}
+
+// ParseChapters reads "Name: start page" lines. Start pages must increase.
+func ParseChapters(text string) ([]database.Chapter, bool) {
+	var chapters []database.Chapter
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		i := strings.LastIndex(line, ":")
+		if i <= 0 {
+			return nil, false
+		}
+		name := strings.TrimSpace(line[:i])
+		page, err := strconv.Atoi(strings.TrimSpace(line[i+1:]))
+		if name == "" || err != nil || page <= 0 {
+			return nil, false
+		}
+		if len(chapters) > 0 && page <= chapters[len(chapters)-1].StartPage {
+			return nil, false
+		}
+		chapters = append(chapters, database.Chapter{Name: name, StartPage: page})
+	}
+	return chapters, len(chapters) > 0
+}
@@ -0,0 +1,65 @@
+package setprogress
+
+import (
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// SetChapterDefault lets members report progress by the last chapter they finished.
+func SetChapterDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	currentBook := database.GetCurrentBook()
+	if currentBook.BookID == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "No active book found.")))
+		return
+	}
+	if len(currentBook.Chapters) == 0 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The chapters of this book are not set yet. Use /setProgress instead.")))
+		return
+	}
+	database.SetUserStatus(user, "enter_chapter")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Which chapter have you finished?"))
+	msg.ReplyMarkup = ChapterKeyboard(currentBook)
+	bot.Send(msg)
+}
+
+func EnterChapter(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	currentBook := database.GetCurrentBook()
+	i, ok := currentBook.FindChapter(update.Message.Text)
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please select a chapter on the keyboard.")))
+		return
+	}
+	progress := database.ReadingProgress{BookID: currentBook.BookID, UserName: user}
+	if userProgress := database.UserProgress(user); userProgress != nil {
+		progress = *userProgress
+	}
+	progress = progress.WithPercent(currentBook.ChapterEnd(i))
+	database.SetProgress(progress)
+	database.SetUserStatus(user, "")
+
+	message := i18n.T(lang, "Thank you! Your progress is now %d%%", progress.Progress)
+	if position := progress.Position(lang); position != "" {
+		message += " (" + position + ")"
+	}
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, message+"."))
+	askForRatingIfFinished(user, progress.Progress, currentBook, bot, update)
+}
+
+// ChapterKeyboard offers the chapters of a book, two per row.
+func ChapterKeyboard(book database.Book) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	for i := 0; i < len(book.Chapters); i += 2 {
+		row := tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(book.Chapters[i].Name))
+		if i+1 < len(book.Chapters) {
+			row = append(row, tgbotapi.NewKeyboardButton(book.Chapters[i+1].Name))
+		}
+		rows = append(rows, row)
+	}
+	keyboard := tgbotapi.NewReplyKeyboard(rows...)
+	keyboard.OneTimeKeyboard = true
+	return keyboard
+}
@@ -0,0 +1,105 @@
+package setprogress
+
+import (
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+)
+
+var totalPrompts = map[database.BookType]string{
+	database.RegularBook: "Enter total pages of the book:",
+	database.KindleBook:  "Enter total Kindle locations of the book:",
+	database.AudioBook:   "Enter total duration of the audiobook (hh:mm):",
+}
+
+var positionPrompts = map[database.BookType]string{
+	database.RegularBook: "Enter the page you are currently reading:",
+	database.EBook:       "Enter percent of the book you have read:",
+	database.KindleBook:  "Enter your current Kindle location:",
+	database.AudioBook:   "Enter how much you have listened so far (hh:mm):",
+}
+
+// TotalPrompt asks for the length of a book of bookType. E-books have none.
+func TotalPrompt(lang string, bookType database.BookType) string {
+	return i18n.T(lang, totalPrompts[bookType])
+}
+
+// PositionPrompt asks where the member is in a book of bookType.
+func PositionPrompt(lang string, bookType database.BookType) string {
+	return i18n.T(lang, positionPrompts[bookType])
+}
+
+// ParseTotal reads the length of a book of bookType: pages, Kindle locations or
+// a duration in hh:mm. On bad input it returns the hint to send instead.
+func ParseTotal(lang string, bookType database.BookType, text string) (int, string) {
+	text = strings.TrimSpace(text)
+	if bookType == database.AudioBook {
+		minutes, err := utils.ParseDuration(text)
+		if err != nil || minutes <= 0 {
+			return 0, i18n.T(lang, "Please enter the duration in format hh:mm, e.g. 09:45.")
+		}
+		return minutes, ""
+	}
+	total, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, i18n.T(lang, "Please enter a number.")
+	}
+	if total <= 0 {
+		return 0, i18n.T(lang, "Please enter a number greater than 0.")
+	}
+	return total, ""
+}
+
+// ParsePosition reads the member's page, percent, Kindle location or listened time
+// and returns progress moved there. The position may not exceed progress.Total().
+// On bad input it returns the hint to send instead.
+func ParsePosition(lang string, progress database.ReadingProgress, text string) (database.ReadingProgress, string) {
+	text = strings.TrimSpace(text)
+	switch progress.Type {
+	case database.EBook:
+		percent, err := strconv.Atoi(strings.TrimSuffix(text, "%"))
+		if err != nil {
+			return progress, i18n.T(lang, "Please enter a number.")
+		}
+		if percent < 0 || percent > 100 {
+			return progress, i18n.T(lang, "Please enter a number between 0 and 100.")
+		}
+		progress.Progress = percent
+		return progress, ""
+	case database.AudioBook:
+		minutes, err := utils.ParseDuration(text)
+		if err != nil {
+			return progress, i18n.T(lang, "Please enter the time in format hh:mm, e.g. 02:30.")
+		}
+		if minutes > progress.TotalMinutes {
+			return progress, i18n.T(lang, "Please enter a time less than or equal to the total duration - %s.", utils.FormatDuration(progress.TotalMinutes))
+		}
+		progress.ListenedMinutes = minutes
+		progress.Progress = utils.Percent(minutes, progress.TotalMinutes)
+		return progress, ""
+	}
+
+	position, err := strconv.Atoi(text)
+	if err != nil {
+		return progress, i18n.T(lang, "Please enter a number.")
+	}
+	if position <= 0 {
+		return progress, i18n.T(lang, "Please enter a number greater than 0.")
+	}
+	switch progress.Type {
+	case database.RegularBook:
+		if position > progress.TotalPages {
+			return progress, i18n.T(lang, "Please enter a number less than or equal to the total number of pages - %d.", progress.TotalPages)
+		}
+		progress.PageNumber = position
+	case database.KindleBook:
+		if position > progress.TotalLocations {
+			return progress, i18n.T(lang, "Please enter a number less than or equal to the total number of locations - %d.", progress.TotalLocations)
+		}
+		progress.Location = position
+	}
+	progress.Progress = utils.Percent(position, progress.Total())
+	return progress, ""
+}
@@ -0,0 +1,64 @@
+package setprogress
+
+import (
+	"telegram-bot/database"
+	"testing"
+)
+
+func TestParseTotal(t *testing.T) {
+	tests := []struct {
+		bookType database.BookType
+		text     string
+		want     int
+		ok       bool
+	}{
+		{database.RegularBook, " 320 ", 320, true},
+		{database.KindleBook, "5400", 5400, true},
+		{database.AudioBook, "09:45", 585, true},
+		{database.RegularBook, "0", 0, false},
+		{database.RegularBook, "many", 0, false},
+		{database.AudioBook, "00:00", 0, false},
+		{database.AudioBook, "585", 0, false},
+	}
+	for _, tt := range tests {
+		got, problem := ParseTotal("en", tt.bookType, tt.text)
+		if (problem == "") != tt.ok || got != tt.want {
+			t.Errorf("ParseTotal(%s, %q) = %d, %q; want %d, ok %v", tt.bookType, tt.text, got, problem, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	regular := database.ReadingProgress{Type: database.RegularBook, TotalPages: 300}
+	kindle := database.ReadingProgress{Type: database.KindleBook, TotalLocations: 4000}
+	audio := database.ReadingProgress{Type: database.AudioBook, TotalMinutes: 600}
+	ebook := database.ReadingProgress{Type: database.EBook}
+	tests := []struct {
+		progress database.ReadingProgress
+		text     string
+		want     int // Progress in percent
+		ok       bool
+	}{
+		{regular, "150", 50, true},
+		{regular, "300", 100, true},
+		{regular, "301", 0, false},
+		{regular, "0", 0, false},
+		{kindle, "1000", 25, true},
+		{kindle, "4001", 0, false},
+		{audio, "02:30", 25, true},
+		{audio, "10:01", 0, false},
+		{ebook, "42%", 42, true},
+		{ebook, "101", 0, false},
+		{ebook, "half", 0, false},
+	}
+	for _, tt := range tests {
+		got, problem := ParsePosition("en", tt.progress, tt.text)
+		if (problem == "") != tt.ok {
+			t.Errorf("ParsePosition(%s, %q) problem = %q, want ok %v", tt.progress.Type, tt.text, problem, tt.ok)
+			continue
+		}
+		if tt.ok && got.Progress != tt.want {
+			t.Errorf("ParsePosition(%s, %q).Progress = %d, want %d", tt.progress.Type, tt.text, got.Progress, tt.want)
+		}
+	}
+}
@@ -1,141 +1,294 @@
 package setprogress
 
 import (
-	"fmt"
 	"log"
-	"strconv"
+	"math"
 	"strings"
 	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/ratebook"
+	"telegram-bot/utils"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func EnterTotalPages(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	totalPages, err := strconv.Atoi(update.Message.Text)
-	if err != nil {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a number."))
-
-	}
-	if totalPages <= 0 {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a number greater than 0."))
-
+	lang := database.UserLanguage(user)
+	totalPages, ok := ParseTotalPages(lang, bot, update)
+	if !ok {
+		return
 	}
 	currentBook := database.GetCurrentBook()
 	bookId := currentBook.BookID
 	database.SetProgress(database.ReadingProgress{BookID: bookId, UserName: user, Type: database.RegularBook, TotalPages: totalPages})
 	database.SetUserStatus(user, "enter_page")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter the page you are currently reading:"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.RegularBook)))
 }
 
-func EnterPage(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	page, err := strconv.Atoi(update.Message.Text)
-	if err != nil {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a number."))
+func SetTotalPagesDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	userProgress := database.UserProgress(user)
+	if userProgress == nil || userProgress.Type != database.RegularBook {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "You are not reading a regular book. Use /setProgress to choose your format or /changeFormat to switch to a regular book.")))
 		return
 	}
-	if page <= 0 {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a number greater than 0."))
+	database.SetUserStatus(user, "enter_corrected_total_pages")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.N(lang, userProgress.TotalPages, "Your edition currently has %d page. Enter the correct total pages of the book:", "Your edition currently has %d pages. Enter the correct total pages of the book:", userProgress.TotalPages)))
+}
+
+func EnterCorrectedTotalPages(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	totalPages, ok := ParseTotalPages(lang, bot, update)
+	if !ok {
+		return
+	}
+	userProgress, ok := CurrentProgress(user, bot, update)
+	if !ok {
+		return
+	}
+	corrected := userProgress.WithTotalPages(totalPages)
+	database.SetProgress(corrected)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Total pages updated. Your progress is now page %d/%d (%d%%).", corrected.PageNumber, corrected.TotalPages, corrected.Progress)))
+}
+
+// TotalPagesPrompt asks for the page count, offering the editions known for the book.
+func TotalPagesPrompt(chatID int64, book database.Book, lang string) tgbotapi.MessageConfig {
+	msg := tgbotapi.NewMessage(chatID, TotalPrompt(lang, database.RegularBook))
+	if len(book.Editions) == 0 {
+		return msg
+	}
+	msg.Text = i18n.T(lang, "Select your edition or enter total pages of the book:")
+	var rows [][]tgbotapi.KeyboardButton
+	for _, edition := range book.Editions {
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(edition.Label(lang))))
+	}
+	keyboard := tgbotapi.NewReplyKeyboard(rows...)
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	return msg
+}
+
+// TotalDurationPrompt asks for the audiobook length, offering the duration known for the book.
+func TotalDurationPrompt(chatID int64, book database.Book, lang string) tgbotapi.MessageConfig {
+	msg := tgbotapi.NewMessage(chatID, TotalPrompt(lang, database.AudioBook))
+	if book.AudioMinutes > 0 {
+		keyboard := tgbotapi.NewReplyKeyboard(
+			tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(utils.FormatDuration(book.AudioMinutes))),
+		)
+		keyboard.OneTimeKeyboard = true
+		msg.ReplyMarkup = keyboard
+	}
+	return msg
+}
+
+// ParseTotalPages validates the page count and asks again on bad input.
+// Edition buttons offered by TotalPagesPrompt are accepted as well.
+func ParseTotalPages(lang string, bot *tgbotapi.BotAPI, update tgbotapi.Update) (int, bool) {
+	text := strings.TrimSpace(update.Message.Text)
+	for _, edition := range database.GetCurrentBook().Editions {
+		if text == edition.Label(lang) {
+			return edition.Pages, true
+		}
+	}
+	totalPages, problem := ParseTotal(lang, database.RegularBook, text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
+		return 0, false
+	}
+	return totalPages, true
+}
+
+func EnterPage(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	userProgress, ok := CurrentProgress(user, bot, update)
+	if !ok {
 		return
 	}
-	userProgress := database.UserProgress(user)
 	totalPages := userProgress.TotalPages
-	if page > totalPages {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a number less than or equal to the total number of pages - "+strconv.Itoa(totalPages)+"."))
+	if totalPages <= 0 {
+		database.SetUserStatus(user, "enter_total_pages")
+		bot.Send(TotalPagesPrompt(update.Message.Chat.ID, database.GetCurrentBook(), lang))
+		return
+	}
+	entered, problem := ParsePosition(lang, *userProgress, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
 		return
 	}
 	currentBook := database.GetCurrentBook()
 	bookId := currentBook.BookID
-	progress := float64(page) / float64(totalPages) * 100
-	database.SetProgress(database.ReadingProgress{BookID: bookId, UserName: user, Type: database.RegularBook, PageNumber: page, Progress: int(progress), TotalPages: totalPages})
+	page, progress := entered.PageNumber, entered.Progress
+	database.SetProgress(database.ReadingProgress{BookID: bookId, UserName: user, Type: database.RegularBook, PageNumber: page, Progress: progress, TotalPages: totalPages})
 	database.SetUserStatus(user, "")
 
 	// Calculate how many pages need to be read per day if there's a meeting date
-	message := "Thank you!"
-	if currentBook.MeetingDate != "" {
-		meetingDate, err := time.Parse("02.01.2006", currentBook.MeetingDate)
-		if err == nil && meetingDate.After(time.Now()) {
-			daysRemaining := int(meetingDate.Sub(time.Now()).Hours() / 24)
-			if daysRemaining > 0 {
-				pagesLeft := totalPages - page
-				pagesPerDay := float64(pagesLeft) / float64(daysRemaining)
-				message += fmt.Sprintf("\nYou need to read %.1f pages per day to finish the book by the meeting date %s.", pagesPerDay, currentBook.MeetingDate)
-			}
-		}
+	message := i18n.T(lang, "Thank you!")
+	if daysRemaining := daysUntilMeeting(user, currentBook); daysRemaining > 0 {
+		pagesLeft := totalPages - page
+		pagesPerDay := int(math.Ceil(float64(pagesLeft) / float64(daysRemaining)))
+		message += "\n" + i18n.N(lang, pagesPerDay, "You need to read %d page per day to finish the book by the meeting date %s.", "You need to read %d pages per day to finish the book by the meeting date %s.", pagesPerDay, currentBook.MeetingDate)
 	}
 
 	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, message))
+	askForRatingIfFinished(user, progress, currentBook, bot, update)
 }
 
 func EnterPercent(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	percent, err := strconv.Atoi(update.Message.Text)
-	if err != nil {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a number."))
+	lang := database.UserLanguage(user)
+	entered, problem := ParsePosition(lang, database.ReadingProgress{Type: database.EBook}, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
 		return
 	}
-	if percent < 0 || percent > 100 {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a number between 0 and 100."))
+	percent := entered.Progress
+
+	currentBook := database.GetCurrentBook()
+	bookId := currentBook.BookID
+
+	database.SetProgress(database.ReadingProgress{BookID: bookId, UserName: user, Type: database.EBook, Progress: percent})
+	database.SetUserStatus(user, "")
+
+	message := i18n.T(lang, "Thank you for updating your e-book progress!")
+	if daysRemaining := daysUntilMeeting(user, currentBook); daysRemaining > 0 {
+		percentLeft := 100 - percent
+		percentPerDay := float64(percentLeft) / float64(daysRemaining)
+		message += "\n" + i18n.T(lang, "You need to read %.1f%% of the book per day to finish it by the meeting date %s.", percentPerDay, currentBook.MeetingDate)
+	}
+
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, message))
+	askForRatingIfFinished(user, percent, currentBook, bot, update)
+}
+
+func EnterTotalLocations(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	totalLocations, problem := ParseTotal(lang, database.KindleBook, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
 		return
 	}
+	currentBook := database.GetCurrentBook()
+	database.SetProgress(database.ReadingProgress{BookID: currentBook.BookID, UserName: user, Type: database.KindleBook, TotalLocations: totalLocations})
+	database.SetUserStatus(user, "enter_location")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.KindleBook)))
+}
 
+func EnterLocation(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	userProgress, ok := CurrentProgress(user, bot, update)
+	if !ok {
+		return
+	}
+	totalLocations := userProgress.TotalLocations
+	entered, problem := ParsePosition(lang, *userProgress, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
+		return
+	}
 	currentBook := database.GetCurrentBook()
-	bookId := currentBook.BookID
+	location, progress := entered.Location, entered.Progress
+	database.SetProgress(database.ReadingProgress{BookID: currentBook.BookID, UserName: user, Type: database.KindleBook, Location: location, TotalLocations: totalLocations, Progress: progress})
+	database.SetUserStatus(user, "")
+
+	message := i18n.T(lang, "Thank you!")
+	if daysRemaining := daysUntilMeeting(user, currentBook); daysRemaining > 0 {
+		locationsPerDay := float64(totalLocations-location) / float64(daysRemaining)
+		message += "\n" + i18n.T(lang, "You need to read %.1f locations per day to finish the book by the meeting date %s.", locationsPerDay, currentBook.MeetingDate)
+	}
+
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, message))
+	askForRatingIfFinished(user, progress, currentBook, bot, update)
+}
 
-	database.SetProgress(database.ReadingProgress{BookID: bookId, UserName: user, Type: database.AudioBook, Progress: percent})
+func EnterTotalDuration(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	totalMinutes, problem := ParseTotal(lang, database.AudioBook, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
+		return
+	}
+	currentBook := database.GetCurrentBook()
+	database.SetProgress(database.ReadingProgress{BookID: currentBook.BookID, UserName: user, Type: database.AudioBook, TotalMinutes: totalMinutes})
+	database.SetUserStatus(user, "enter_listened_time")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.AudioBook)))
+}
+
+func EnterListenedTime(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	userProgress, ok := CurrentProgress(user, bot, update)
+	if !ok {
+		return
+	}
+	totalMinutes := userProgress.TotalMinutes
+	entered, problem := ParsePosition(lang, *userProgress, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
+		return
+	}
+	currentBook := database.GetCurrentBook()
+	listenedMinutes, progress := entered.ListenedMinutes, entered.Progress
+	database.SetProgress(database.ReadingProgress{BookID: currentBook.BookID, UserName: user, Type: database.AudioBook, ListenedMinutes: listenedMinutes, TotalMinutes: totalMinutes, Progress: progress})
 	database.SetUserStatus(user, "")
 
-	message := "Thank you for updating your audiobook progress!"
-	if currentBook.MeetingDate != "" {
-		meetingDate, err := time.Parse("02.01.2006", currentBook.MeetingDate)
-		if err == nil && meetingDate.After(time.Now()) {
-			daysRemaining := int(meetingDate.Sub(time.Now()).Hours() / 24)
-			if daysRemaining > 0 {
-				percentLeft := 100 - percent
-				percentPerDay := float64(percentLeft) / float64(daysRemaining)
-				message += fmt.Sprintf("\nYou need to complete %.1f%% of the audiobook per day to finish it by the meeting date %s.", percentPerDay, currentBook.MeetingDate)
-			}
-		}
+	message := i18n.T(lang, "Thank you for updating your audiobook progress!")
+	if daysRemaining := daysUntilMeeting(user, currentBook); daysRemaining > 0 {
+		minutesPerDay := (totalMinutes - listenedMinutes + daysRemaining - 1) / daysRemaining
+		message += "\n" + i18n.T(lang, "You need to listen %s per day to finish the audiobook by the meeting date %s.", utils.FormatDuration(minutesPerDay), currentBook.MeetingDate)
 	}
 
 	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, message))
+	askForRatingIfFinished(user, progress, currentBook, bot, update)
 }
 
 func EnterBookType(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	message := strings.ToLower(update.Message.Text)
-	if strings.Contains(message, "regular") {
-		currentBook := database.GetCurrentBook()
-		bookId := currentBook.BookID
-		database.SetProgress(database.ReadingProgress{BookID: bookId, UserName: user, Type: database.RegularBook})
-		database.SetUserStatus(user, "enter_total_pages")
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter total pages of the book:"))
+	lang := database.UserLanguage(user)
+	bookType, ok := ParseBookType(update.Message.Text)
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, I didn't understand you. Please select the book type - regular, e-book, kindle or audio:")))
 		return
 	}
-	if strings.Contains(message, "audio") {
-		currentBook := database.GetCurrentBook()
-		bookId := currentBook.BookID
-		database.SetProgress(database.ReadingProgress{BookID: bookId, UserName: user, Type: database.AudioBook})
+	currentBook := database.GetCurrentBook()
+	database.SetProgress(database.ReadingProgress{BookID: currentBook.BookID, UserName: user, Type: bookType})
+	switch bookType {
+	case database.RegularBook:
+		database.SetUserStatus(user, "enter_total_pages")
+		bot.Send(TotalPagesPrompt(update.Message.Chat.ID, currentBook, lang))
+	case database.KindleBook:
+		database.SetUserStatus(user, "enter_total_locations")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, TotalPrompt(lang, database.KindleBook)))
+	case database.EBook:
 		database.SetUserStatus(user, "enter_percent")
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter percent of your listening:"))
-		return
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.EBook)))
+	case database.AudioBook:
+		database.SetUserStatus(user, "enter_total_duration")
+		bot.Send(TotalDurationPrompt(update.Message.Chat.ID, currentBook, lang))
 	}
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Sorry, I didn't understand you. Please select the book type - audio or regular:"))
+}
+
+// ParseBookType recognises the book type from a keyboard button or free text.
+func ParseBookType(text string) (database.BookType, bool) {
+	message := strings.ToLower(text)
+	switch {
+	case strings.Contains(message, "regular") || strings.Contains(message, "paper") || strings.Contains(message, "бумаж"):
+		return database.RegularBook, true
+	case strings.Contains(message, "kindle") || strings.Contains(message, "киндл"):
+		return database.KindleBook, true
+	case strings.Contains(message, "e-book") || strings.Contains(message, "ebook") || strings.Contains(message, "электрон"):
+		return database.EBook, true
+	case strings.Contains(message, "audio") || strings.Contains(message, "аудио"):
+		return database.AudioBook, true
+	}
+	return "", false
 }
 
 func SetProgressDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	userProgress := database.UserProgress(user)
 	if userProgress == nil {
 		database.SetUserStatus(user, "enter_book_type")
-		msg := tgbotapi.NewMessage(update.Message.Chat.ID, "Select the book's type (audio or regular):")
-
-		keyboard := tgbotapi.NewReplyKeyboard(
-			tgbotapi.NewKeyboardButtonRow(
-				tgbotapi.NewKeyboardButton("Regular Book"),
-				tgbotapi.NewKeyboardButton("Audio Book"),
-			),
-		)
-		keyboard.OneTimeKeyboard = true // Make keyboard disappear after use
-
-		msg.ReplyMarkup = keyboard
+		msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Select the book's type (regular, e-book, kindle or audio):"))
+		msg.ReplyMarkup = BookTypeKeyboard(lang)
 
 		_, err := bot.Send(msg)
 		if err != nil {
@@ -143,12 +296,77 @@ func SetProgressDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Updat
 		}
 		return
 	}
-	if userProgress.Type == database.RegularBook {
+	switch userProgress.Type {
+	case database.RegularBook:
 		database.SetUserStatus(user, "enter_page")
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter the page you are currently reading:"))
-	}
-	if userProgress.Type == database.AudioBook {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.RegularBook)))
+	case database.EBook:
 		database.SetUserStatus(user, "enter_percent")
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter percent of your listening:"))
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.EBook)))
+	case database.KindleBook:
+		database.SetUserStatus(user, "enter_location")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.KindleBook)))
+	case database.AudioBook:
+		// Audiobooks used to be tracked by percent only, so older records have no duration yet.
+		if userProgress.TotalMinutes == 0 {
+			database.SetUserStatus(user, "enter_total_duration")
+			bot.Send(TotalDurationPrompt(update.Message.Chat.ID, database.GetCurrentBook(), lang))
+			return
+		}
+		database.SetUserStatus(user, "enter_listened_time")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, PositionPrompt(lang, database.AudioBook)))
+	}
+}
+
+// CurrentProgress returns the member's progress in the current book. If there is
+// none, e.g. because a new book was added in the middle of the dialog, it ends
+// the dialog and asks the member to start over.
+func CurrentProgress(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) (*database.ReadingProgress, bool) {
+	userProgress := database.UserProgress(user)
+	if userProgress == nil {
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(database.UserLanguage(user), "Your progress for the current book was not found. Please start again with /setProgress.")))
+		return nil, false
+	}
+	return userProgress, true
+}
+
+// askForRatingIfFinished starts the rating dialog when the member reaches 100%.
+func askForRatingIfFinished(user string, progress int, book database.Book, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	if progress < 100 || database.HasRated(book.BookID, user) {
+		return
+	}
+	ratebook.AskForRating(user, book, bot, update.Message.Chat.ID)
+}
+
+func BookTypeKeyboard(lang string) tgbotapi.ReplyKeyboardMarkup {
+	keyboard := tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Regular Book")),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "E-book")),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton("Kindle"),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, "Audio Book")),
+		),
+	)
+	keyboard.OneTimeKeyboard = true // Make keyboard disappear after use
+	return keyboard
+}
+
+// daysUntilMeeting returns the number of calendar days left before the book's meeting
+// as seen from the member's timezone, or 0 if there is no upcoming meeting.
+func daysUntilMeeting(user string, book database.Book) int {
+	if book.MeetingDate == "" {
+		return 0
+	}
+	meetingDate, err := utils.ParseDate(book.MeetingDate, database.ClubLocation())
+	if err != nil {
+		return 0
+	}
+	daysRemaining := utils.DaysBetween(time.Now().In(database.UserLocation(user)), meetingDate)
+	if daysRemaining < 0 {
+		return 0
 	}
+	return daysRemaining
 }
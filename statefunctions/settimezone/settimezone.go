@@ -0,0 +1,60 @@
+package settimezone
+
+import (
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/utils"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const useClubTimezone = "Use club timezone"
+
+func SetClubTimezoneDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserStatus(user, "enter_club_timezone")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The club timezone is %s. Enter the new timezone, e.g. Europe/Moscow:", database.ClubTimezone())))
+}
+
+func EnterClubTimezone(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	timezone := strings.TrimSpace(update.Message.Text)
+	if !utils.IsValidTimezone(timezone) {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Unknown timezone. Please enter a name like Europe/Moscow or Asia/Tbilisi.")))
+		return
+	}
+	database.SetClubSetting("Timezone", timezone)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The club timezone is now %s.", timezone)))
+}
+
+func SetUserTimezoneDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserStatus(user, "enter_user_timezone")
+	now := time.Now().In(database.UserLocation(user))
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Your time is %s. Enter your timezone, e.g. Europe/Berlin:", now.Format("15:04 (MST)")))
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, useClubTimezone))))
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func EnterUserTimezone(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	timezone := strings.TrimSpace(update.Message.Text)
+	if timezone == i18n.T(lang, useClubTimezone) {
+		database.SetUserTimezone(user, "")
+		database.SetUserStatus(user, "")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "You are using the club timezone %s.", database.ClubTimezone())))
+		return
+	}
+	if !utils.IsValidTimezone(timezone) {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Unknown timezone. Please enter a name like Europe/Moscow or Asia/Tbilisi.")))
+		return
+	}
+	database.SetUserTimezone(user, timezone)
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Your timezone is now %s.", timezone)))
+}
@@ -1,32 +1,49 @@
 package setuser
 
 import (
+	"strings"
 	"telegram-bot/database"
+	"telegram-bot/i18n"
 	"telegram-bot/utils"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func SetUserDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	database.SetUserStatus(user, "enter_nickname")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter user telegram nick name:"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter user telegram nick name:")))
 }
 
 func EnterUserNickName(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
 	nickName := update.Message.Text
 	if !utils.IsValidTelegramNickname(nickName) {
-		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Please enter a valid nickname."))
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter a valid nickname.")))
 		return
 	}
-	database.AddUser(nickName, "")
+	if database.IsUserExists(nickName) {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "@%s is already a member of the club. Enter another nickname:", nickName)))
+		return
+	}
+	// The member is only added once the full name is known, see EnterUserName.
+	database.SetUserDraft(user, nickName)
 	database.SetUserStatus(user, "enter_username")
 
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Enter full user name:"))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter full user name:")))
 }
 
 func EnterUserName(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
-	userName := update.Message.Text
-	database.SetUserFullName(userName)
+	lang := database.UserLanguage(user)
+	nickName := database.UserDraft(user)
+	userName := strings.TrimSpace(update.Message.Text)
+	database.SetUserDraft(user, "")
 	database.SetUserStatus(user, "")
-	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, "Thank you!"))
+	if database.IsUserExists(nickName) {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "@%s is already a member of the club.", nickName)))
+		return
+	}
+	database.AddUser(nickName, userName)
+	database.RecordAudit(user, database.ActionAddUser, nickName, nil, database.GetUserDetails(nickName))
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Thank you!")))
 }
@@ -0,0 +1,365 @@
+package shelf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"telegram-bot/statefunctions/setprogress"
+	"telegram-bot/utils"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	skip           = "Skip"
+	updateProgress = "Update progress"
+	nominate       = "Nominate for the club"
+	withdraw       = "Withdraw nomination"
+	remove         = "Remove"
+)
+
+var shelves = []database.Shelf{database.Reading, database.WantToRead, database.Read}
+
+// Caps that keep /shelf and /updateShelf within one Telegram message even after
+// a Goodreads library of hundreds of books was imported.
+const (
+	shelfLimit    = 15 // books listed per shelf by /shelf
+	keyboardLimit = 20 // books offered on the /updateShelf keyboard
+)
+
+// shelfTitles are the keyboard buttons and the headings of /shelf.
+var shelfTitles = map[database.Shelf]string{
+	database.WantToRead: "Want to read",
+	database.Reading:    "Reading",
+	database.Read:       "Read",
+}
+
+// parseShelf accepts a shelf title in the member's language or in English.
+func parseShelf(lang, text string) (database.Shelf, bool) {
+	for shelf, title := range shelfTitles {
+		if strings.EqualFold(text, i18n.T(lang, title)) || strings.EqualFold(text, title) {
+			return shelf, true
+		}
+	}
+	return "", false
+}
+
+func AddToShelfDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if title := strings.TrimSpace(update.Message.CommandArguments()); title != "" {
+		addTitle(user, title, bot, update)
+		return
+	}
+	database.SetUserStatus(user, "shelf_enter_title")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the title of the book:")))
+}
+
+func EnterShelfTitle(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	title := utils.NormalizeQuotes(strings.TrimSpace(update.Message.Text))
+	if title == "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please enter the title of the book.")))
+		return
+	}
+	addTitle(user, title, bot, update)
+}
+
+func addTitle(user, title string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	database.SetUserDraft(user, database.AddShelfBook(user, title))
+	database.SetUserStatus(user, "shelf_enter_author")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Enter the author:"))
+	msg.ReplyMarkup = keyboard([]string{i18n.T(lang, skip)})
+	bot.Send(msg)
+}
+
+func EnterShelfAuthor(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if author := strings.TrimSpace(update.Message.Text); author != i18n.T(lang, skip) && author != "" {
+		book := database.GetShelfBook(user, database.UserDraft(user))
+		book.Author = author
+		database.PutShelfBook(book)
+	}
+	database.SetUserStatus(user, "shelf_select_shelf")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Which shelf should it go on?"))
+	msg.ReplyMarkup = shelfKeyboard(lang)
+	bot.Send(msg)
+}
+
+func SelectShelf(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	shelf, ok := parseShelf(lang, strings.TrimSpace(update.Message.Text))
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please select a shelf on the keyboard.")))
+		return
+	}
+	book := database.GetShelfBook(user, database.UserDraft(user))
+	book.Shelf = shelf
+	database.PutShelfBook(book)
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "\"%s\" is on your %s shelf. See your shelf with /shelf.", book.Title, shelf.Label(lang))))
+}
+
+// FormatShelf lists a member's shelf grouped by shelf.
+func FormatShelf(user, lang string) string {
+	books := database.UserShelf(user)
+	if len(books) == 0 {
+		return i18n.T(lang, "Your shelf is empty. Add a book with /addToShelf.")
+	}
+	result := i18n.T(lang, "Your shelf:") + "\n"
+	for _, shelf := range shelves {
+		var onShelf []database.ShelfBook
+		for _, book := range books {
+			if book.Shelf == shelf {
+				onShelf = append(onShelf, book)
+			}
+		}
+		if len(onShelf) == 0 {
+			continue
+		}
+		result += "\n" + i18n.T(lang, shelfTitles[shelf]) + fmt.Sprintf(" (%d):\n", len(onShelf))
+		for _, book := range onShelf[:min(len(onShelf), shelfLimit)] {
+			result += "- " + describe(book, lang) + "\n"
+		}
+		if len(onShelf) > shelfLimit {
+			result += i18n.N(lang, len(onShelf)-shelfLimit, "…and %d more", "…and %d more", len(onShelf)-shelfLimit) + "\n"
+		}
+	}
+	return result + "\n" + i18n.T(lang, "Use /updateShelf to track progress, move or nominate a book.")
+}
+
+func describe(book database.ShelfBook, lang string) string {
+	text := book.Title
+	if book.Author != "" {
+		text = i18n.T(lang, "%s by %s", book.Title, book.Author)
+	}
+	if book.Shelf == database.Reading && book.Progress.Type != "" {
+		text += fmt.Sprintf(", %d%%", book.Progress.Progress)
+		if position := book.Progress.Position(lang); position != "" {
+			text += " (" + position + ")"
+		}
+	}
+	if book.Nominated {
+		text += " [" + i18n.T(lang, "nominated") + "]"
+	}
+	return text
+}
+
+func UpdateShelfDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	books := database.UserShelf(user)
+	if len(books) == 0 {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Your shelf is empty. Add a book with /addToShelf.")))
+		return
+	}
+	// Books being read come first, then the other shelves and books left without
+	// one by an unfinished /addToShelf. The rest can be chosen by typing the title.
+	var labels []string
+	for _, shelf := range append(append([]database.Shelf{}, shelves...), "") {
+		for i, book := range books {
+			if book.Shelf == shelf && len(labels) < keyboardLimit {
+				labels = append(labels, bookChoice(i, book))
+			}
+		}
+	}
+	database.SetUserStatus(user, "shelf_select_book")
+	text := i18n.T(lang, "Which book?")
+	if len(books) > len(labels) {
+		text += " " + i18n.T(lang, "The keyboard shows %d of %d books, type the title of any other.", len(labels), len(books))
+	}
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, text)
+	msg.ReplyMarkup = keyboard(labels)
+	bot.Send(msg)
+}
+
+func bookChoice(i int, book database.ShelfBook) string {
+	return strconv.Itoa(i+1) + ". " + book.Title
+}
+
+func SelectShelfBook(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	var selected *database.ShelfBook
+	books := database.UserShelf(user)
+	for i, book := range books {
+		if text == bookChoice(i, book) || strings.EqualFold(text, book.Title) || text == strconv.Itoa(i+1) {
+			selected = &books[i]
+			break
+		}
+	}
+	if selected == nil {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please select a book on the keyboard.")))
+		return
+	}
+	database.SetUserDraft(user, selected.ShelfID)
+	database.SetUserStatus(user, "shelf_select_action")
+	nomination := nominate
+	if selected.Nominated {
+		nomination = withdraw
+	}
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, describe(*selected, lang)+"\n"+i18n.T(lang, "What do you want to do?"))
+	msg.ReplyMarkup = tgbotapi.NewReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(i18n.T(lang, updateProgress))),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, shelfTitles[database.WantToRead])),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, shelfTitles[database.Reading])),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, shelfTitles[database.Read])),
+		),
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButton(i18n.T(lang, nomination)),
+			tgbotapi.NewKeyboardButton(i18n.T(lang, remove)),
+		),
+	)
+	bot.Send(msg)
+}
+
+func SelectShelfAction(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	text := strings.TrimSpace(update.Message.Text)
+	book := database.GetShelfBook(user, database.UserDraft(user))
+	if shelf, ok := parseShelf(lang, text); ok {
+		book.Shelf = shelf
+		database.PutShelfBook(book)
+		done(user, i18n.T(lang, "\"%s\" moved to your %s shelf.", book.Title, shelf.Label(lang)), bot, update)
+		return
+	}
+	switch text {
+	case i18n.T(lang, updateProgress):
+		if book.Progress.Type == "" {
+			database.SetUserStatus(user, "shelf_enter_book_type")
+			msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Select the book's type (regular, e-book, kindle or audio):"))
+			msg.ReplyMarkup = setprogress.BookTypeKeyboard(lang)
+			bot.Send(msg)
+			return
+		}
+		askPosition(user, book.Progress, bot, update)
+	case i18n.T(lang, nominate), i18n.T(lang, withdraw):
+		book.Nominated = text == i18n.T(lang, nominate)
+		database.PutShelfBook(book)
+		if book.Nominated {
+			done(user, i18n.T(lang, "Thank you! \"%s\" is nominated for the club. Everybody can see the nominations with /nominations.", book.Title), bot, update)
+		} else {
+			done(user, i18n.T(lang, "The nomination is withdrawn."), bot, update)
+		}
+	case i18n.T(lang, remove):
+		database.RemoveShelfBook(user, book.ShelfID)
+		done(user, i18n.T(lang, "\"%s\" is removed from your shelf.", book.Title), bot, update)
+	default:
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Please select an action on the keyboard.")))
+	}
+}
+
+func EnterShelfBookType(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	bookType, ok := setprogress.ParseBookType(update.Message.Text)
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Sorry, I didn't understand you. Please select the book type - regular, e-book, kindle or audio:")))
+		return
+	}
+	book := database.GetShelfBook(user, database.UserDraft(user))
+	book.Progress = database.ReadingProgress{UserName: user, BookID: book.ShelfID, Type: bookType}
+	database.PutShelfBook(book)
+	askPosition(user, book.Progress, bot, update)
+}
+
+func EnterShelfTotal(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	book := database.GetShelfBook(user, database.UserDraft(user))
+	total, problem := setprogress.ParseTotal(lang, book.Progress.Type, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
+		return
+	}
+	switch book.Progress.Type {
+	case database.RegularBook:
+		book.Progress.TotalPages = total
+	case database.KindleBook:
+		book.Progress.TotalLocations = total
+	case database.AudioBook:
+		book.Progress.TotalMinutes = total
+	}
+	database.PutShelfBook(book)
+	askPosition(user, book.Progress, bot, update)
+}
+
+// askPosition asks where the member is in the book, or first for its length if
+// that is still missing, e.g. because the member left after choosing the type.
+func askPosition(user string, progress database.ReadingProgress, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	if progress.Type != database.EBook && progress.Total() == 0 {
+		database.SetUserStatus(user, "shelf_enter_total")
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, setprogress.TotalPrompt(lang, progress.Type)))
+		return
+	}
+	database.SetUserStatus(user, "shelf_enter_position")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, setprogress.PositionPrompt(lang, progress.Type)))
+}
+
+func EnterShelfPosition(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	book := database.GetShelfBook(user, database.UserDraft(user))
+	progress, problem := setprogress.ParsePosition(lang, book.Progress, update.Message.Text)
+	if problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, problem))
+		return
+	}
+	book.Progress = progress
+	switch {
+	case progress.Progress >= 100:
+		book.Shelf = database.Read
+	case progress.Progress > 0:
+		book.Shelf = database.Reading
+	}
+	database.PutShelfBook(book)
+
+	message := i18n.T(lang, "Thank you! You have read %d%% of \"%s\".", progress.Progress, book.Title)
+	if book.Shelf == database.Read {
+		message += " " + i18n.T(lang, "It is now on your read shelf.")
+	}
+	done(user, message, bot, update)
+}
+
+// FormatNominations lists the books members suggest for the club.
+func FormatNominations(lang string) string {
+	nominations := database.Nominations()
+	if len(nominations) == 0 {
+		return i18n.T(lang, "There are no nominations yet. Nominate a book from your shelf with /updateShelf.")
+	}
+	result := i18n.T(lang, "Nominations for the club:") + "\n"
+	for _, book := range nominations {
+		if book.Author != "" {
+			result += "\n" + i18n.T(lang, "%s by %s", book.Title, book.Author)
+		} else {
+			result += "\n" + book.Title
+		}
+		result += " (" + i18n.T(lang, "nominated by %s", database.GetUserDetails(book.UserName).FullName) + ")"
+	}
+	return result + "\n\n" + i18n.T(lang, "Admins can add a nominated book to the queue with /queueBook.")
+}
+
+func done(user, text string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, text))
+}
+
+func shelfKeyboard(lang string) tgbotapi.ReplyKeyboardMarkup {
+	return keyboard([]string{
+		i18n.T(lang, shelfTitles[database.WantToRead]),
+		i18n.T(lang, shelfTitles[database.Reading]),
+		i18n.T(lang, shelfTitles[database.Read]),
+	})
+}
+
+func keyboard(labels []string) tgbotapi.ReplyKeyboardMarkup {
+	var rows [][]tgbotapi.KeyboardButton
+	for _, label := range labels {
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButton(label)))
+	}
+	keyboard := tgbotapi.NewReplyKeyboard(rows...)
+	keyboard.OneTimeKeyboard = true
+	return keyboard
+}
@@ -0,0 +1,224 @@
+// Package undo reverts an admin's last action from the audit log, using the
+// records saved before and after it.
+package undo
+
+import (
+	"log"
+	"strings"
+	"telegram-bot/database"
+	"telegram-bot/i18n"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// GracePeriod is how long after an action it can still be undone.
+const GracePeriod = 15 * time.Minute
+
+// UndoDefault answers /undo by asking to confirm the undo of the admin's last action.
+func UndoDefault(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	entries, ok := lastAction(user, time.Now())
+	if !ok {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "There is nothing to undo. Only your own actions from the last %d minutes can be undone.", int(GracePeriod.Minutes()))))
+		return
+	}
+	if problem := conflicts(entries, lang); problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Your last action can't be undone: ")+problem))
+		return
+	}
+	database.SetUserDraft(user, entries[0].EventID)
+	database.SetUserStatus(user, "confirm_undo")
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Undo your last action: %s?", describe(entries, lang)))
+	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(
+		tgbotapi.NewKeyboardButton(i18n.T(lang, "Yes")),
+		tgbotapi.NewKeyboardButton(i18n.T(lang, "No")),
+	))
+	keyboard.OneTimeKeyboard = true
+	msg.ReplyMarkup = keyboard
+	bot.Send(msg)
+}
+
+func ConfirmUndo(user string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	lang := database.UserLanguage(user)
+	eventID := database.UserDraft(user)
+	database.SetUserDraft(user, "")
+	database.SetUserStatus(user, "")
+	if !strings.EqualFold(strings.TrimSpace(update.Message.Text), i18n.T(lang, "Yes")) {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Nothing was undone.")))
+		return
+	}
+
+	// Check again: the grace period may have run out while the admin was deciding.
+	entries, ok := lastAction(user, time.Now())
+	if !ok || entries[0].EventID != eventID {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "The grace period is over, the action can no longer be undone.")))
+		return
+	}
+	if problem := conflicts(entries, lang); problem != "" {
+		bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Your last action can't be undone: ")+problem))
+		return
+	}
+	for _, entry := range entries {
+		revert(entry)
+		database.RecordUndo(user, entry)
+		log.Printf("%s undid %s %s", user, entry.Action, entry.Target)
+	}
+	bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, i18n.T(lang, "Undone: %s.", describe(entries, lang))))
+}
+
+// lastAction returns the newest action of user within the grace period that
+// hasn't been undone yet. Once it is undone, the action before it comes next.
+// One /addBook records the book and then its meeting date; the two are returned
+// together, newest first, so that they are undone as one.
+func lastAction(user string, now time.Time) ([]database.AuditEntry, bool) {
+	return latest(database.AuditLog(), user, now)
+}
+
+// latest finds the last action of user in auditLog, which is sorted newest first.
+func latest(auditLog []database.AuditEntry, user string, now time.Time) ([]database.AuditEntry, bool) {
+	undone := map[string]bool{}
+	var entries []database.AuditEntry
+	for _, entry := range auditLog {
+		if now.Sub(entry.Timestamp) > GracePeriod {
+			break
+		}
+		if entry.Action == database.ActionUndo {
+			undone[entry.Undoes] = true
+			continue
+		}
+		if entry.Actor != user || undone[entry.EventID] {
+			continue
+		}
+		if len(entries) == 0 && firstMeetingDate(entry) {
+			entries = append(entries, entry)
+			continue
+		}
+		if len(entries) > 0 && (entry.Action != database.ActionAddBook || entry.Target != entries[0].Target) {
+			break
+		}
+		return append(entries, entry), true
+	}
+	return entries, len(entries) > 0
+}
+
+// firstMeetingDate reports whether entry set the meeting date of a book that had
+// none, as the last step of /addBook does.
+func firstMeetingDate(entry database.AuditEntry) bool {
+	if entry.Action != database.ActionUpdateBookDate {
+		return false
+	}
+	var before database.Book
+	entry.Records(&before, nil)
+	return before.MeetingDate == ""
+}
+
+func conflicts(entries []database.AuditEntry, lang string) string {
+	for _, entry := range entries {
+		if problem := conflict(entry, lang); problem != "" {
+			return problem
+		}
+	}
+	return ""
+}
+
+// describe lists what entries changed, oldest first.
+func describe(entries []database.AuditEntry, lang string) string {
+	var descriptions []string
+	for i := len(entries) - 1; i >= 0; i-- {
+		descriptions = append(descriptions, entries[i].Describe(lang))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// conflict explains why entry can't be undone any more, or returns "" if it can.
+// Changes made since the action would otherwise be lost.
+func conflict(entry database.AuditEntry, lang string) string {
+	switch entry.Action {
+	case database.ActionAddBook:
+		var before, after database.Book
+		entry.Records(&before, &after)
+		if database.GetCurrentBook().BookID != after.BookID {
+			return i18n.T(lang, "\"%s\" is no longer the current book.", after.Title)
+		}
+		if before.BookID != "" && database.GetBook(before.BookID).BookID == "" {
+			return i18n.T(lang, "\"%s\" has been removed.", before.Title)
+		}
+		if hasMemberRecords(database.GetBook(after.BookID)) {
+			return i18n.T(lang, "members have already recorded progress, ratings, notes, quotes or RSVPs for \"%s\".", after.Title)
+		}
+	case database.ActionUpdateBookDate:
+		var after database.Book
+		entry.Records(nil, &after)
+		if database.GetBook(after.BookID).MeetingDate != after.MeetingDate {
+			return i18n.T(lang, "the meeting date of \"%s\" has changed since.", after.Title)
+		}
+	case database.ActionAddUser:
+		if !database.IsUserExists(entry.Target) {
+			return i18n.T(lang, "@%s is no longer a member.", entry.Target)
+		}
+	case database.ActionRemoveUser:
+		var before database.User
+		entry.Records(&before, nil)
+		if before.UserName == "" {
+			return i18n.T(lang, "@%s was not a member.", entry.Target)
+		}
+		if database.IsUserExists(entry.Target) {
+			return i18n.T(lang, "@%s is a member again.", entry.Target)
+		}
+	}
+	return ""
+}
+
+// hasMemberRecords reports whether any member has recorded something against book.
+// Removing the book would leave those records behind, still counted by /history, /me and /leaderboard.
+func hasMemberRecords(book database.Book) bool {
+	if len(database.BookProgress(book.BookID)) > 0 || len(database.BookRatings(book.BookID)) > 0 ||
+		len(database.BookNotes(book.BookID)) > 0 || len(database.BookQuotes(book.BookID)) > 0 {
+		return true
+	}
+	for _, event := range database.ProgressHistory() {
+		if event.BookID == book.BookID {
+			return true
+		}
+	}
+	for _, meeting := range database.BookMeetings(book) {
+		if len(database.MeetingRSVPs(meeting.MeetingID)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// revert carries out the inverse of entry. An added book is only removed while no
+// member has recorded anything against it, see conflict. A removed member's reading
+// progress is never deleted, so it comes back with the record.
+func revert(entry database.AuditEntry) {
+	switch entry.Action {
+	case database.ActionAddBook:
+		var before, after database.Book
+		entry.Records(&before, &after)
+		for _, meeting := range database.BookMeetings(database.GetBook(after.BookID)) {
+			database.RemoveMeeting(meeting.MeetingID)
+		}
+		database.RemoveBook(after.BookID)
+		if before.BookID != "" {
+			database.SetActiveBook(before.BookID)
+		}
+	case database.ActionUpdateBookDate:
+		var before database.Book
+		entry.Records(&before, nil)
+		database.UpdateBookDate(before.BookID, before.MeetingDate)
+		if before.MeetingDate != "" {
+			database.SetFinalMeetingDate(before.BookID, before.MeetingDate)
+		} else {
+			database.RemoveMeeting(database.FinalMeetingID(before.BookID))
+		}
+	case database.ActionAddUser:
+		database.RemoveUser(entry.Target)
+	case database.ActionRemoveUser:
+		var before database.User
+		entry.Records(&before, nil)
+		database.RestoreUser(before)
+	}
+}
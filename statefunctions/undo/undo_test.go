@@ -0,0 +1,65 @@
+package undo
+
+import (
+	"encoding/json"
+	"telegram-bot/database"
+	"testing"
+	"time"
+)
+
+func record(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestLatest(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := func(minutesAgo int) time.Time { return now.Add(-time.Duration(minutesAgo) * time.Minute) }
+	book := database.Book{BookID: "b2", Title: "Dune"}
+	dated := book
+	dated.MeetingDate = "20.11.2026"
+	redated := dated
+	redated.MeetingDate = "27.11.2026"
+
+	addBook := database.AuditEntry{EventID: "1", Timestamp: at(5), Actor: "alice", Action: database.ActionAddBook, Target: "b2", After: record(t, book)}
+	setDate := database.AuditEntry{EventID: "2", Timestamp: at(4), Actor: "alice", Action: database.ActionUpdateBookDate, Target: "b2", Before: record(t, book), After: record(t, dated)}
+	changeDate := database.AuditEntry{EventID: "3", Timestamp: at(2), Actor: "alice", Action: database.ActionUpdateBookDate, Target: "b2", Before: record(t, dated), After: record(t, redated)}
+	addUser := database.AuditEntry{EventID: "4", Timestamp: at(1), Actor: "bob", Action: database.ActionAddUser, Target: "carol"}
+	undo := func(id string, entry database.AuditEntry) database.AuditEntry {
+		return database.AuditEntry{EventID: id, Timestamp: at(0), Actor: "alice", Action: database.ActionUndo, Undoes: entry.EventID}
+	}
+
+	tests := []struct {
+		name string
+		log  []database.AuditEntry // newest first
+		want []string
+	}{
+		{"addBook and its date go together", []database.AuditEntry{addUser, setDate, addBook}, []string{"2", "1"}},
+		{"a later date change goes alone", []database.AuditEntry{addUser, changeDate, setDate, addBook}, []string{"3"}},
+		{"after undoing the change the whole addBook comes next", []database.AuditEntry{undo("5", changeDate), addUser, changeDate, setDate, addBook}, []string{"2", "1"}},
+		{"all undone", []database.AuditEntry{undo("6", addBook), undo("5", setDate), setDate, addBook}, nil},
+		{"older than the grace period", []database.AuditEntry{{EventID: "0", Timestamp: at(16), Actor: "alice", Action: database.ActionAddUser}}, nil},
+		{"other admins' actions are skipped", []database.AuditEntry{addUser}, nil},
+	}
+	for _, tt := range tests {
+		entries, ok := latest(tt.log, "alice", now)
+		var got []string
+		for _, entry := range entries {
+			got = append(got, entry.EventID)
+		}
+		if ok != (len(tt.want) > 0) || len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
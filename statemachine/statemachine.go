@@ -2,10 +2,24 @@ package statemachine
 
 import (
 	"log"
+	"telegram-bot/statefunctions/addmeeting"
+	"telegram-bot/statefunctions/addmilestone"
+	"telegram-bot/statefunctions/addnote"
+	"telegram-bot/statefunctions/addquote"
+	"telegram-bot/statefunctions/bulkimport"
+	"telegram-bot/statefunctions/changeformat"
+	"telegram-bot/statefunctions/profile"
+	"telegram-bot/statefunctions/queuebook"
+	"telegram-bot/statefunctions/ratebook"
 	"telegram-bot/statefunctions/removeuser"
+	"telegram-bot/statefunctions/setactivebook"
 	"telegram-bot/statefunctions/setbook"
+	"telegram-bot/statefunctions/setbookinfo"
 	"telegram-bot/statefunctions/setprogress"
+	"telegram-bot/statefunctions/settimezone"
 	"telegram-bot/statefunctions/setuser"
+	"telegram-bot/statefunctions/shelf"
+	"telegram-bot/statefunctions/undo"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -13,17 +27,70 @@ import (
 type FuncType func(string, string, *tgbotapi.BotAPI, tgbotapi.Update)
 
 var FuncMap = map[string]FuncType{
-	"enter_page":               SetProgress,
-	"enter_percent":            SetProgress,
-	"enter_book_type":          SetProgress,
-	"enter_total_pages":        SetProgress,
-	"enter_book_name":          SetBook,
-	"enter_author":             SetBook,
-	"enter_finishing_date":     SetBook,
-	"enter_nickname":           AddUser,
-	"enter_username":           AddUser,
-	"enter_name":               AddUser,
-	"enter_nickname_to_remove": RemoveUser,
+	"enter_page":                SetProgress,
+	"enter_percent":             SetProgress,
+	"enter_book_type":           SetProgress,
+	"enter_total_pages":         SetProgress,
+	"enter_total_locations":     SetProgress,
+	"enter_location":            SetProgress,
+	"enter_total_duration":      SetProgress,
+	"enter_listened_time":       SetProgress,
+	"enter_chapter":             SetChapter,
+	"enter_milestone_target":    AddMilestone,
+	"enter_milestone_deadline":  AddMilestone,
+	"enter_new_book_type":       ChangeFormat,
+	"enter_new_total_pages":     ChangeFormat,
+	"enter_new_total_locations": ChangeFormat,
+	"enter_new_total_duration":  ChangeFormat,
+	"enter_book_name":           SetBook,
+	"select_book_result":        SetBook,
+	"enter_book_title":          SetBook,
+	"enter_author":              SetBook,
+	"confirm_finishing_date":    SetBook,
+	"enter_finishing_date":      SetBook,
+	"select_book_info_field":    SetBookInfo,
+	"enter_book_info_value":     SetBookInfo,
+	"enter_meeting_title":       AddMeeting,
+	"enter_meeting_date":        AddMeeting,
+	"confirm_meeting_date":      AddMeeting,
+	"enter_meeting_time":        AddMeeting,
+	"enter_meeting_timezone":    AddMeeting,
+	"enter_meeting_place":       AddMeeting,
+	"enter_meeting_agenda":      AddMeeting,
+	"enter_club_timezone":       SetClubTimezone,
+	"enter_user_timezone":       SetUserTimezone,
+	"enter_book_to_activate":    SetActiveBook,
+	"enter_queue_title":         QueueBook,
+	"enter_queue_author":        QueueBook,
+	"enter_queue_start":         QueueBook,
+	"enter_queue_meeting":       QueueBook,
+	"enter_queue_order":         ReorderQueue,
+	"enter_book_to_unqueue":     UnqueueBook,
+	"enter_note_position":       AddNote,
+	"enter_note_text":           AddNote,
+	"enter_quote_text":          AddQuote,
+	"enter_quote_reference":     AddQuote,
+	"select_profile_setting":    Profile,
+	"enter_display_name":        Profile,
+	"enter_language":            SetLanguage,
+	"enter_club_language":       SetClubLanguage,
+	"enter_import_file":         Import,
+	"confirm_import":            Import,
+	"confirm_undo":              Undo,
+	"shelf_enter_title":         AddToShelf,
+	"shelf_enter_author":        AddToShelf,
+	"shelf_select_shelf":        AddToShelf,
+	"shelf_select_book":         UpdateShelf,
+	"shelf_select_action":       UpdateShelf,
+	"shelf_enter_book_type":     UpdateShelf,
+	"shelf_enter_total":         UpdateShelf,
+	"shelf_enter_position":      UpdateShelf,
+	"enter_rating":              RateBook,
+	"enter_review":              RateBook,
+	"enter_nickname":            AddUser,
+	"enter_username":            AddUser,
+	"enter_name":                AddUser,
+	"enter_nickname_to_remove":  RemoveUser,
 }
 
 func SetProgress(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
@@ -38,6 +105,42 @@ func SetProgress(user string, userStatus string, bot *tgbotapi.BotAPI, update tg
 		setprogress.EnterBookType(user, bot, update)
 	case "enter_total_pages":
 		setprogress.EnterTotalPages(user, bot, update)
+	case "enter_total_locations":
+		setprogress.EnterTotalLocations(user, bot, update)
+	case "enter_location":
+		setprogress.EnterLocation(user, bot, update)
+	case "enter_total_duration":
+		setprogress.EnterTotalDuration(user, bot, update)
+	case "enter_listened_time":
+		setprogress.EnterListenedTime(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetTotalPages(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		setprogress.SetTotalPagesDefault(user, bot, update)
+	case "enter_corrected_total_pages":
+		setprogress.EnterCorrectedTotalPages(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func ChangeFormat(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		changeformat.ChangeFormatDefault(user, bot, update)
+	case "enter_new_book_type":
+		changeformat.EnterNewBookType(user, bot, update)
+	case "enter_new_total_pages":
+		changeformat.EnterNewTotalPages(user, bot, update)
+	case "enter_new_total_locations":
+		changeformat.EnterNewTotalLocations(user, bot, update)
+	case "enter_new_total_duration":
+		changeformat.EnterNewTotalDuration(user, bot, update)
 	default:
 		log.Fatal("There is no status - " + userStatus)
 	}
@@ -49,10 +152,280 @@ func SetBook(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbota
 		setbook.SetBookDefault(user, bot, update)
 	case "enter_book_name":
 		setbook.EnterBookName(user, bot, update)
+	case "select_book_result":
+		setbook.SelectBookResult(user, bot, update)
+	case "enter_book_title":
+		setbook.EnterBookTitle(user, bot, update)
 	case "enter_author":
 		setbook.EnterAuthor(user, bot, update)
 	case "enter_finishing_date":
 		setbook.EnterFinishingDate(user, bot, update)
+	case "confirm_finishing_date":
+		setbook.ConfirmFinishingDate(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetBookInfo(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		setbookinfo.SetBookInfoDefault(user, bot, update)
+	case "select_book_info_field":
+		setbookinfo.SelectBookInfoField(user, bot, update)
+	case "enter_book_info_value":
+		setbookinfo.EnterBookInfoValue(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func AddMeeting(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		addmeeting.AddMeetingDefault(user, bot, update)
+	case "enter_meeting_title":
+		addmeeting.EnterMeetingTitle(user, bot, update)
+	case "enter_meeting_date":
+		addmeeting.EnterMeetingDate(user, bot, update)
+	case "confirm_meeting_date":
+		addmeeting.ConfirmMeetingDate(user, bot, update)
+	case "enter_meeting_time":
+		addmeeting.EnterMeetingTime(user, bot, update)
+	case "enter_meeting_timezone":
+		addmeeting.EnterMeetingTimezone(user, bot, update)
+	case "enter_meeting_place":
+		addmeeting.EnterMeetingPlace(user, bot, update)
+	case "enter_meeting_agenda":
+		addmeeting.EnterMeetingAgenda(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetClubTimezone(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		settimezone.SetClubTimezoneDefault(user, bot, update)
+	case "enter_club_timezone":
+		settimezone.EnterClubTimezone(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetUserTimezone(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		settimezone.SetUserTimezoneDefault(user, bot, update)
+	case "enter_user_timezone":
+		settimezone.EnterUserTimezone(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetActiveBook(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		setactivebook.SetActiveBookDefault(user, bot, update)
+	case "enter_book_to_activate":
+		setactivebook.EnterBookToActivate(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func QueueBook(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		queuebook.QueueBookDefault(user, bot, update)
+	case "enter_queue_title":
+		queuebook.EnterQueueTitle(user, bot, update)
+	case "enter_queue_author":
+		queuebook.EnterQueueAuthor(user, bot, update)
+	case "enter_queue_start":
+		queuebook.EnterQueueStart(user, bot, update)
+	case "enter_queue_meeting":
+		queuebook.EnterQueueMeeting(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func ReorderQueue(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		queuebook.ReorderQueueDefault(user, bot, update)
+	case "enter_queue_order":
+		queuebook.EnterQueueOrder(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func UnqueueBook(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		queuebook.UnqueueBookDefault(user, bot, update)
+	case "enter_book_to_unqueue":
+		queuebook.EnterBookToUnqueue(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetChapter(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		setprogress.SetChapterDefault(user, bot, update)
+	case "enter_chapter":
+		setprogress.EnterChapter(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func AddMilestone(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		addmilestone.AddMilestoneDefault(user, bot, update)
+	case "enter_milestone_target":
+		addmilestone.EnterMilestoneTarget(user, bot, update)
+	case "enter_milestone_deadline":
+		addmilestone.EnterMilestoneDeadline(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func AddNote(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		addnote.AddNoteDefault(user, bot, update)
+	case "enter_note_position":
+		addnote.EnterNotePosition(user, bot, update)
+	case "enter_note_text":
+		addnote.EnterNoteText(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func AddQuote(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		addquote.AddQuoteDefault(user, bot, update)
+	case "enter_quote_text":
+		addquote.EnterQuoteText(user, bot, update)
+	case "enter_quote_reference":
+		addquote.EnterQuoteReference(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func Profile(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		profile.ProfileDefault(user, bot, update)
+	case "select_profile_setting":
+		profile.SelectProfileSetting(user, bot, update)
+	case "enter_display_name":
+		profile.EnterDisplayName(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetLanguage(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		profile.LanguageDefault(user, bot, update)
+	case "enter_language":
+		profile.EnterLanguage(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func SetClubLanguage(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		profile.SetClubLanguageDefault(user, bot, update)
+	case "enter_club_language":
+		profile.EnterClubLanguage(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func Import(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		bulkimport.ImportDefault(user, bot, update)
+	case "enter_import_file":
+		bulkimport.EnterImportFile(user, bot, update)
+	case "confirm_import":
+		bulkimport.ConfirmImport(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func Undo(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		undo.UndoDefault(user, bot, update)
+	case "confirm_undo":
+		undo.ConfirmUndo(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func AddToShelf(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		shelf.AddToShelfDefault(user, bot, update)
+	case "shelf_enter_title":
+		shelf.EnterShelfTitle(user, bot, update)
+	case "shelf_enter_author":
+		shelf.EnterShelfAuthor(user, bot, update)
+	case "shelf_select_shelf":
+		shelf.SelectShelf(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func UpdateShelf(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		shelf.UpdateShelfDefault(user, bot, update)
+	case "shelf_select_book":
+		shelf.SelectShelfBook(user, bot, update)
+	case "shelf_select_action":
+		shelf.SelectShelfAction(user, bot, update)
+	case "shelf_enter_book_type":
+		shelf.EnterShelfBookType(user, bot, update)
+	case "shelf_enter_total":
+		shelf.EnterShelfTotal(user, bot, update)
+	case "shelf_enter_position":
+		shelf.EnterShelfPosition(user, bot, update)
+	default:
+		log.Fatal("There is no status - " + userStatus)
+	}
+}
+
+func RateBook(user string, userStatus string, bot *tgbotapi.BotAPI, update tgbotapi.Update) {
+	switch userStatus {
+	case "":
+		ratebook.RateBookDefault(user, bot, update)
+	case "enter_rating":
+		ratebook.EnterRating(user, bot, update)
+	case "enter_review":
+		ratebook.EnterReview(user, bot, update)
 	default:
 		log.Fatal("There is no status - " + userStatus)
 	}
@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func NormalizeQuotes(s string) string {
@@ -18,3 +21,90 @@ func IsValidTelegramNickname(nickname string) bool {
 	validNickname := regexp.MustCompile(regex)
 	return validNickname.MatchString(nickname)
 }
+
+// Percent returns part of total as a whole percent, capped to 0..100.
+func Percent(part, total int) int {
+	if total <= 0 || part <= 0 {
+		return 0
+	}
+	if part >= total {
+		return 100
+	}
+	return int(float64(part) / float64(total) * 100)
+}
+
+// ParseDuration parses an "hh:mm" duration into minutes.
+func ParseDuration(s string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid duration %q, expected hh:mm", s)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 {
+		return 0, fmt.Errorf("invalid hours in %q", s)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("invalid minutes in %q", s)
+	}
+	return hours*60 + minutes, nil
+}
+
+// FormatDuration formats minutes as "hh:mm".
+func FormatDuration(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// IsValidISBN checks the length and check digit of an ISBN-10 or ISBN-13 without hyphens.
+func IsValidISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			digit := int(r - '0')
+			if r == 'X' && i == 9 {
+				digit = 10
+			} else if r < '0' || r > '9' {
+				return false
+			}
+			sum += digit * (10 - i)
+		}
+		return sum%11 == 0
+	case 13:
+		sum := 0
+		for i, r := range isbn {
+			if r < '0' || r > '9' {
+				return false
+			}
+			digit := int(r - '0')
+			if i%2 == 1 {
+				digit *= 3
+			}
+			sum += digit
+		}
+		return sum%10 == 0
+	}
+	return false
+}
+
+// ParseDate parses a "dd.mm.yyyy" date as midnight in loc.
+func ParseDate(date string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("02.01.2006", strings.TrimSpace(date), loc)
+}
+
+// DaysBetween counts calendar days from the date of from to the date of to,
+// each taken in its own timezone, so the result doesn't depend on the time of day.
+func DaysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// IsValidTimezone checks that name is an IANA timezone such as "Europe/Moscow".
+func IsValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}